@@ -0,0 +1,206 @@
+/*
+Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
+*/
+package cmd
+
+import (
+	"api-gateway/internal/application/dto"
+	"api-gateway/internal/application/usecases"
+	"api-gateway/internal/config"
+	"api-gateway/internal/domain/entities"
+	"api-gateway/internal/infrastructure"
+	"api-gateway/pkg/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// apikeyCmd groups the API key lifecycle commands. Results are written to
+// stdout as JSON, logs go to stderr, so the output can be piped in scripts.
+var apikeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Manage API keys",
+	Long:  "Create, list, inspect, rotate and revoke API keys in the configured key store, or hash keys for seeding",
+	// Flags and arguments are validated by now; store errors should not print usage
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cmd.SilenceUsage = true
+	},
+}
+
+var (
+	apikeyOwner     string
+	apikeyScopes    []string
+	apikeyRoles     []string
+	apikeyPlan      string
+	apikeyExpiresAt string
+	apikeyTTL       time.Duration
+)
+
+var apikeyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key; the key is only printed once",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req := &dto.CreateApiKeyRequest{
+			Owner:  apikeyOwner,
+			Scopes: apikeyScopes,
+			Roles:  apikeyRoles,
+			Plan:   apikeyPlan,
+		}
+
+		switch {
+		case apikeyExpiresAt != "" && apikeyTTL != 0:
+			return fmt.Errorf("--expires-at and --ttl are mutually exclusive")
+		case apikeyExpiresAt != "":
+			expiresAt, err := time.Parse(time.RFC3339, apikeyExpiresAt)
+			if err != nil {
+				return fmt.Errorf("invalid --expires-at, expected RFC3339: %w", err)
+			}
+			req.ExpiresAt = &expiresAt
+		case apikeyTTL != 0:
+			expiresAt := time.Now().Add(apikeyTTL)
+			req.ExpiresAt = &expiresAt
+		}
+
+		return withApiKeyUseCase(func(ctx context.Context, useCase usecases.ApiKeyManagementUseCases) (interface{}, error) {
+			return useCase.Create(ctx, cliActor(), req)
+		})
+	},
+}
+
+var apikeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withApiKeyUseCase(func(ctx context.Context, useCase usecases.ApiKeyManagementUseCases) (interface{}, error) {
+			return useCase.List(ctx)
+		})
+	},
+}
+
+var apikeyInspectCmd = &cobra.Command{
+	Use:   "inspect <id>",
+	Short: "Show an API key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withApiKeyUseCase(func(ctx context.Context, useCase usecases.ApiKeyManagementUseCases) (interface{}, error) {
+			return useCase.Get(ctx, args[0])
+		})
+	},
+}
+
+var apikeyRotateCmd = &cobra.Command{
+	Use:   "rotate <id>",
+	Short: "Replace the secret of an API key; the new key is only printed once",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withApiKeyUseCase(func(ctx context.Context, useCase usecases.ApiKeyManagementUseCases) (interface{}, error) {
+			return useCase.Rotate(ctx, cliActor(), args[0])
+		})
+	},
+}
+
+var apikeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withApiKeyUseCase(func(ctx context.Context, useCase usecases.ApiKeyManagementUseCases) (interface{}, error) {
+			if err := useCase.Revoke(ctx, cliActor(), args[0]); err != nil {
+				return nil, err
+			}
+			return map[string]string{"id": args[0], "status": "revoked"}, nil
+		})
+	},
+}
+
+var apikeyHashCmd = &cobra.Command{
+	Use:   "hash [key]",
+	Short: "Print a seed entry for api_keys.seed or --seed-keys; generates a key when none is given",
+	Long: "Hash an API key into an entry for the api_keys.seed config section or a --seed-keys file. " +
+		"Without a key argument a new key is generated and included in the output. Does not connect to the key store.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seed := map[string]string{}
+		var key string
+		if len(args) == 1 {
+			key = args[0]
+		} else {
+			id, secret, err := entities.GenerateApiKey()
+			if err != nil {
+				return fmt.Errorf("failed to generate api key: %w", err)
+			}
+			key = entities.FormatApiKey(id, secret)
+			seed["key"] = key
+		}
+
+		id, secret := entities.ParseApiKey(key)
+		record := &entities.ApiKey{ID: id}
+		if err := record.SetSecret(secret); err != nil {
+			return fmt.Errorf("failed to hash api key secret: %w", err)
+		}
+		seed["id"] = record.ID
+		seed["salt"] = record.Salt
+		seed["secret_hash"] = record.SecretHash
+
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(seed)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(apikeyCmd)
+	apikeyCmd.AddCommand(apikeyCreateCmd, apikeyListCmd, apikeyInspectCmd, apikeyRotateCmd, apikeyRevokeCmd, apikeyHashCmd)
+
+	apikeyCreateCmd.Flags().StringVar(&apikeyOwner, "owner", "", "consumer the key is issued to")
+	apikeyCreateCmd.Flags().StringSliceVar(&apikeyScopes, "scope", nil, "scope granted to the key (repeatable)")
+	apikeyCreateCmd.Flags().StringSliceVar(&apikeyRoles, "role", nil, "role granted to the key (repeatable)")
+	apikeyCreateCmd.Flags().StringVar(&apikeyPlan, "plan", "", "usage quota plan of the key, defaults to quotas.default_plan")
+	apikeyCreateCmd.Flags().StringVar(&apikeyExpiresAt, "expires-at", "", "expiry as RFC3339 timestamp")
+	apikeyCreateCmd.Flags().DurationVar(&apikeyTTL, "ttl", 0, "expiry relative to now, e.g. 720h")
+	apikeyCreateCmd.MarkFlagRequired("owner")
+}
+
+// withApiKeyUseCase connects to the key store configured for the gateway,
+// runs fn and prints its result as JSON
+func withApiKeyUseCase(fn func(ctx context.Context, useCase usecases.ApiKeyManagementUseCases) (interface{}, error)) error {
+	log := logger.New(env)
+
+	cfg, err := config.Load(configFile, env)
+	if err != nil {
+		log.Error("Failed to load configuration", "error", err)
+		return err
+	}
+
+	connections, err := infrastructure.NewDatabaseConnections(cfg, log)
+	if err != nil {
+		log.Error("Failed to initialize database connections", "error", err)
+		return err
+	}
+	apiKeyRepo := connections.GetApiKeyRepo()
+	if closer, ok := apiKeyRepo.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := fn(ctx, usecases.NewApiKeyManagementUseCase(apiKeyRepo, log))
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(rootCmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func cliActor() dto.ApiKeyActor {
+	return dto.ApiKeyActor{Source: "cli"}
+}
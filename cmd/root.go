@@ -17,9 +17,10 @@ var rootCmd = &cobra.Command{
 }
 
 var (
-	configFile string
-	port       string
-	env        string
+	configFile   string
+	port         string
+	env          string
+	seedKeysFile string
 )
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -30,6 +30,7 @@ func init() {
 
 	// Add server-specific flags
 	serverCmd.Flags().StringVarP(&port, "port", "p", "", "server port")
+	serverCmd.Flags().StringVar(&seedKeysFile, "seed-keys", "", "YAML or JSON file of pre-hashed API keys to seed on startup")
 }
 
 func runServer(cmd *cobra.Command, args []string) error {
@@ -51,6 +52,11 @@ func runServer(cmd *cobra.Command, args []string) error {
 		log.Info("Port overridden by command line flag", "port", port)
 	}
 
+	if cmd.Flags().Changed("seed-keys") {
+		cfg.ApiKeys.SeedFile = seedKeysFile
+		log.Info("API key seed file set by command line flag", "seed_file", seedKeysFile)
+	}
+
 	log.Info("Configuration loaded",
 		"env", cfg.Environment,
 		"port", cfg.Server.Port,
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// BasicAuthValidator checks user names and passwords against an htpasswd
+// file or, for the api_keys source, against the API key store
+type BasicAuthValidator struct {
+	apiKeyRepo ports.ApiKeyRepository
+	logger     logger.Logger
+}
+
+func NewBasicAuthValidator(log logger.Logger, apiKeyRepo ports.ApiKeyRepository) *BasicAuthValidator {
+	return &BasicAuthValidator{
+		apiKeyRepo: apiKeyRepo,
+		logger:     log.With("component", "basic_auth_validator"),
+	}
+}
+
+// Validate checks the "user:password" credentials extracted from the Authorization header
+func (v *BasicAuthValidator) Validate(ctx context.Context, credentials string, cfg *entities.BasicAuthConfig) (*entities.Principal, error) {
+	if cfg == nil {
+		return nil, domainErrors.ErrAuthPolicyMissingBasicConfig
+	}
+
+	user, password, found := strings.Cut(credentials, ":")
+	if !found || user == "" {
+		return nil, domainErrors.ErrMissingBasicCredentials
+	}
+
+	if cfg.Source == entities.BasicAuthSourceApiKeys {
+		record, err := findUsableApiKey(ctx, v.apiKeyRepo, v.logger, user+"."+password)
+		if errors.Is(err, domainErrors.ErrInvalidApiKey) {
+			return nil, domainErrors.ErrInvalidBasicCredentials
+		}
+		if err != nil {
+			return nil, err
+		}
+		principal := apiKeyPrincipal(record)
+		principal.AuthType = entities.AuthTypeBasic
+		return principal, nil
+	}
+
+	hash, ok := cfg.Users[user]
+	if !ok {
+		checkPassword(unknownUserHash, password)
+		v.logger.Warn("Unknown basic auth user", "user", user, "realm", cfg.Realm)
+		return nil, domainErrors.ErrInvalidBasicCredentials
+	}
+	if !checkPassword(hash, password) {
+		v.logger.Warn("Basic auth password mismatch", "user", user, "realm", cfg.Realm)
+		return nil, domainErrors.ErrInvalidBasicCredentials
+	}
+
+	return &entities.Principal{
+		Subject:  user,
+		AuthType: entities.AuthTypeBasic,
+	}, nil
+}
+
+// extractBasicCredentials decodes an "Authorization: Basic <base64 user:password>" header
+func extractBasicCredentials(headers map[string][]string) (string, error) {
+	authorization := headers["Authorization"]
+	if len(authorization) == 0 {
+		return "", domainErrors.ErrMissingBasicCredentials
+	}
+
+	scheme, encoded, found := strings.Cut(authorization[0], " ")
+	if !found || !strings.EqualFold(scheme, "Basic") {
+		return "", domainErrors.ErrMissingBasicCredentials
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || !strings.Contains(string(decoded), ":") {
+		return "", domainErrors.ErrMissingBasicCredentials
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,137 @@
+package auth_test
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func bcryptHash(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hash)
+}
+
+func argon2idHash(password string) string {
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte(password), salt, 1, 1024, 1, 32)
+	return fmt.Sprintf("$argon2id$v=%d$m=1024,t=1,p=1$%s$%s", argon2.Version,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func TestParseHtpasswd(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"bcrypt and argon2", "# legacy tools\nalice:" + bcryptHash(t, "a") + "\n\nbob:" + argon2idHash("b") + "\n", false},
+		{"apache md5", "alice:$apr1$abc$0123456789\n", true},
+		{"sha1", "alice:{SHA}qUqP5cyxm6YcTAhz05Hph5gvu9M=\n", true},
+		{"plain text", "alice:secret\n", true},
+		{"missing hash", "alice\n", true},
+		{"duplicate user", "alice:" + bcryptHash(t, "a") + "\nalice:" + bcryptHash(t, "b") + "\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := auth.ParseHtpasswd(strings.NewReader(tt.content))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, users, 2)
+		})
+	}
+}
+
+func TestBasicAuthValidator_Htpasswd(t *testing.T) {
+	cfg := &entities.BasicAuthConfig{
+		Realm: "legacy",
+		Users: map[string]string{
+			"alice": bcryptHash(t, "alice-password"),
+			"bob":   argon2idHash("bob-password"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		credentials string
+		wantErr     error
+	}{
+		{"bcrypt user", "alice:alice-password", nil},
+		{"argon2 user", "bob:bob-password", nil},
+		{"password with colon", "alice:alice-password:extra", domainErrors.ErrInvalidBasicCredentials},
+		{"wrong password", "alice:bob-password", domainErrors.ErrInvalidBasicCredentials},
+		{"unknown user", "carol:alice-password", domainErrors.ErrInvalidBasicCredentials},
+		{"empty user", ":alice-password", domainErrors.ErrMissingBasicCredentials},
+	}
+
+	validator := auth.NewBasicAuthValidator(logger.New("test"), repositories.NewMemoryApiKeyRepository(logger.New("test")))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := validator.Validate(context.Background(), tt.credentials, cfg)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, principal)
+				return
+			}
+			require.NoError(t, err)
+			user, _, _ := strings.Cut(tt.credentials, ":")
+			assert.Equal(t, user, principal.Subject)
+			assert.Equal(t, entities.AuthTypeBasic, principal.AuthType)
+		})
+	}
+}
+
+func TestBasicAuthValidator_ApiKeys(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryApiKeyRepository(logger.New("test"))
+	require.NoError(t, repo.StoreKey(ctx, "legacy1.s3cret", &entities.ApiKey{Owner: "reporting-tool", Roles: []string{"reports"}}))
+	require.NoError(t, repo.StoreKey(ctx, "legacy2.s3cret", &entities.ApiKey{Owner: "old-tool", Disabled: true}))
+
+	validator := auth.NewBasicAuthValidator(logger.New("test"), repo)
+	cfg := &entities.BasicAuthConfig{Source: entities.BasicAuthSourceApiKeys}
+
+	principal, err := validator.Validate(ctx, "legacy1:s3cret", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "reporting-tool", principal.Subject)
+	assert.Equal(t, "legacy1", principal.KeyID)
+	assert.Equal(t, entities.AuthTypeBasic, principal.AuthType)
+	assert.Equal(t, []string{"reports"}, principal.Roles)
+
+	_, err = validator.Validate(ctx, "legacy1:guessed", cfg)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidBasicCredentials)
+
+	_, err = validator.Validate(ctx, "legacy2:s3cret", cfg)
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyDisabled)
+}
+
+func TestAuthValidator_ExtractBasicCredentials(t *testing.T) {
+	validator := auth.NewAuthValidator(logger.New("test"), nil, nil, "")
+	encoded := base64.StdEncoding.EncodeToString([]byte("alice:pa:ss"))
+
+	credentials, err := validator.ExtractToken(context.Background(), map[string][]string{"Authorization": {"Basic " + encoded}}, entities.AuthTypeBasic)
+	require.NoError(t, err)
+	assert.Equal(t, "alice:pa:ss", credentials)
+
+	for _, header := range []string{"Bearer " + encoded, "Basic not-base64!", "Basic " + base64.StdEncoding.EncodeToString([]byte("alice"))} {
+		_, err := validator.ExtractToken(context.Background(), map[string][]string{"Authorization": {header}}, entities.AuthTypeBasic)
+		assert.ErrorIs(t, err, domainErrors.ErrMissingBasicCredentials, header)
+	}
+}
@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultExternalAuthTimeout = 2 * time.Second
+	// gatewayHeaderPrefix marks the identity headers the gateway sets for
+	// backends, copies sent by clients are never shown to the service
+	gatewayHeaderPrefix        = "X-Gateway-"
+	defaultIdentityTokenHeader = "X-Gateway-Identity"
+)
+
+// externalAuthRequest is the request metadata sent to the authorization service
+type externalAuthRequest struct {
+	RouteID   string                 `json:"route_id"`
+	Method    string                 `json:"method"`
+	Host      string                 `json:"host"`
+	Path      string                 `json:"path"`
+	Query     string                 `json:"query,omitempty"`
+	Headers   map[string][]string    `json:"headers"`
+	Principal *externalAuthPrincipal `json:"principal,omitempty"`
+}
+
+// externalAuthPrincipal is the caller authenticated by earlier policies of an all_of composite
+type externalAuthPrincipal struct {
+	Subject  string   `json:"subject"`
+	AuthType string   `json:"auth_type"`
+	KeyID    string   `json:"key_id,omitempty"`
+	Scopes   []string `json:"scopes"`
+	Roles    []string `json:"roles"`
+}
+
+// externalAuthResponse is the decision of the authorization service
+type externalAuthResponse struct {
+	Allow           bool              `json:"allow"`
+	Reason          string            `json:"reason"`
+	Subject         string            `json:"subject"`
+	Scopes          []string          `json:"scopes"`
+	Roles           []string          `json:"roles"`
+	HeadersToAdd    map[string]string `json:"headers_to_add"`
+	HeadersToRemove []string          `json:"headers_to_remove"`
+}
+
+// ExternalAuthorizer delegates the decision to allow a request to an HTTP
+// authorization service and caches its decisions
+type ExternalAuthorizer struct {
+	httpClient *http.Client
+	logger     logger.Logger
+	cache      *resultCache
+	// identityTokenHeader carries the signed identity token to backends
+	identityTokenHeader string
+}
+
+func NewExternalAuthorizer(log logger.Logger, identityTokenHeader string) *ExternalAuthorizer {
+	if identityTokenHeader == "" {
+		identityTokenHeader = defaultIdentityTokenHeader
+	}
+	return &ExternalAuthorizer{
+		httpClient:          &http.Client{},
+		logger:              log.With("component", "external_authorizer"),
+		cache:               newResultCache(defaultResultCacheSize),
+		identityTokenHeader: identityTokenHeader,
+	}
+}
+
+func (a *ExternalAuthorizer) Validate(ctx context.Context, req *entities.HTTPRequest, cfg *entities.ExternalAuthConfig) (*entities.Principal, error) {
+	if cfg == nil {
+		return nil, domainErrors.ErrAuthPolicyMissingExternalConfig
+	}
+
+	payload, err := json.Marshal(externalAuthRequest{
+		RouteID:   req.RouteID,
+		Method:    req.Method,
+		Host:      req.Host,
+		Path:      req.Path,
+		Query:     req.RawQuery,
+		Headers:   a.forwardedHeaders(req.Headers, cfg.ForwardHeaders),
+		Principal: toExternalAuthRequestPrincipal(req.Principal),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validated configs only cache with an explicit header list, so per request
+	// headers such as X-Request-ID do not make every key unique
+	cacheKey := externalAuthCacheKey(cfg.Endpoint, payload)
+	if cfg.CacheTTL > 0 {
+		if result, ok := a.cache.get(cacheKey); ok {
+			a.logger.Debug("External authorization cache hit", "route_id", req.RouteID, "allowed", result.err == nil)
+			return result.principal, result.err
+		}
+	}
+
+	response, err := a.authorize(ctx, payload, cfg)
+	if err != nil {
+		// Failures are not cached so the next request asks the service again
+		if cfg.FailOpen {
+			a.logger.Warn("External authorization failed, allowing request", "route_id", req.RouteID, "endpoint", cfg.Endpoint, "error", err)
+			return &entities.Principal{AuthType: entities.AuthTypeExternal, Claims: map[string]interface{}{"fail_open": true}}, nil
+		}
+		a.logger.Error("External authorization failed", "route_id", req.RouteID, "endpoint", cfg.Endpoint, "error", err)
+		return nil, domainErrors.ErrExternalAuthorizationUnavailable
+	}
+
+	principal, err := toExternalAuthPrincipal(response)
+	if err != nil {
+		a.logger.Warn("Request denied by external authorization", "route_id", req.RouteID, "reason", response.Reason)
+	}
+	if cfg.CacheTTL > 0 {
+		a.cache.put(cacheKey, principal, err, time.Now().Add(cfg.CacheTTL))
+	}
+	return principal, err
+}
+
+func (a *ExternalAuthorizer) authorize(ctx context.Context, payload []byte, cfg *entities.ExternalAuthConfig) (*externalAuthResponse, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultExternalAuthTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorization request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call authorization service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("authorization service returned status %d", resp.StatusCode)
+	}
+
+	var response externalAuthResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&response); err != nil {
+		return nil, fmt.Errorf("invalid authorization response: %w", err)
+	}
+	return &response, nil
+}
+
+func toExternalAuthPrincipal(response *externalAuthResponse) (*entities.Principal, error) {
+	if !response.Allow {
+		return nil, domainErrors.ErrExternalAuthorizationDenied
+	}
+
+	principal := &entities.Principal{
+		Subject:  response.Subject,
+		AuthType: entities.AuthTypeExternal,
+		Scopes:   response.Scopes,
+		Roles:    response.Roles,
+	}
+	if len(response.HeadersToAdd) > 0 || len(response.HeadersToRemove) > 0 {
+		principal.Upstream = &entities.HeaderMutation{
+			Set:    response.HeadersToAdd,
+			Remove: response.HeadersToRemove,
+		}
+	}
+	return principal, nil
+}
+
+// toExternalAuthRequestPrincipal describes the caller already authenticated, if any
+func toExternalAuthRequestPrincipal(principal *entities.Principal) *externalAuthPrincipal {
+	if principal == nil {
+		return nil
+	}
+	return &externalAuthPrincipal{
+		Subject:  principal.Subject,
+		AuthType: principal.AuthType,
+		KeyID:    principal.KeyID,
+		Scopes:   principal.Scopes,
+		Roles:    principal.Roles,
+	}
+}
+
+// forwardedHeaders selects the headers sent to the authorization service, all
+// of them without a list. Gateway identity headers sent by the client are
+// dropped either way, the service learns the caller from the principal.
+func (a *ExternalAuthorizer) forwardedHeaders(headers map[string][]string, names []string) map[string][]string {
+	if len(names) == 0 {
+		names = make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+	}
+
+	selected := make(map[string][]string, len(names))
+	for _, name := range names {
+		name = http.CanonicalHeaderKey(name)
+		if strings.HasPrefix(name, gatewayHeaderPrefix) || name == http.CanonicalHeaderKey(a.identityTokenHeader) {
+			continue
+		}
+		if values := http.Header(headers).Values(name); len(values) > 0 {
+			selected[name] = values
+		}
+	}
+	return selected
+}
+
+// externalAuthCacheKey identifies a decision by everything the service was told about the request
+func externalAuthCacheKey(endpoint string, payload []byte) string {
+	sum := sha256.Sum256(append([]byte(endpoint+"\x00"), payload...))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,156 @@
+package auth_test
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAuthorizationService allows requests from the compliance team and denies everything else
+func newAuthorizationService(t *testing.T, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+
+		var request struct {
+			RouteID string              `json:"route_id"`
+			Method  string              `json:"method"`
+			Path    string              `json:"path"`
+			Headers map[string][]string `json:"headers"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "reports-export", request.RouteID)
+		assert.NotContains(t, request.Headers, "Cookie")
+
+		if http.Header(request.Headers).Get("X-Team") != "compliance" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"allow": false, "reason": "team not allowed"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"allow":             true,
+			"subject":           "compliance",
+			"roles":             []string{"auditor"},
+			"headers_to_add":    map[string]string{"X-Data-Classification": "restricted"},
+			"headers_to_remove": []string{"X-Team"},
+		})
+	}))
+}
+
+func newExternalRequest(team string) *entities.HTTPRequest {
+	return &entities.HTTPRequest{
+		RouteID: "reports-export",
+		Method:  "GET",
+		Host:    "gateway.internal",
+		Path:    "/api/reports/export",
+		Headers: map[string][]string{"X-Team": {team}, "Cookie": {"session=1"}},
+	}
+}
+
+func TestExternalAuthorizer_Validate(t *testing.T) {
+	var calls int32
+	service := newAuthorizationService(t, &calls)
+	defer service.Close()
+
+	cfg := &entities.ExternalAuthConfig{Endpoint: service.URL, ForwardHeaders: []string{"x-team"}}
+	authorizer := auth.NewExternalAuthorizer(logger.New("test"), "")
+
+	principal, err := authorizer.Validate(context.Background(), newExternalRequest("compliance"), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "compliance", principal.Subject)
+	assert.Equal(t, entities.AuthTypeExternal, principal.AuthType)
+	assert.Equal(t, []string{"auditor"}, principal.Roles)
+	assert.Equal(t, &entities.HeaderMutation{
+		Set:    map[string]string{"X-Data-Classification": "restricted"},
+		Remove: []string{"X-Team"},
+	}, principal.Upstream)
+
+	principal, err = authorizer.Validate(context.Background(), newExternalRequest("marketing"), cfg)
+	assert.ErrorIs(t, err, domainErrors.ErrExternalAuthorizationDenied)
+	assert.Nil(t, principal)
+}
+
+func TestExternalAuthorizer_CachesDecisions(t *testing.T) {
+	var calls int32
+	service := newAuthorizationService(t, &calls)
+	defer service.Close()
+
+	cfg := &entities.ExternalAuthConfig{Endpoint: service.URL, CacheTTL: time.Minute, ForwardHeaders: []string{"X-Team"}}
+	authorizer := auth.NewExternalAuthorizer(logger.New("test"), "")
+
+	for i := 0; i < 3; i++ {
+		_, err := authorizer.Validate(context.Background(), newExternalRequest("compliance"), cfg)
+		require.NoError(t, err)
+		_, err = authorizer.Validate(context.Background(), newExternalRequest("marketing"), cfg)
+		assert.ErrorIs(t, err, domainErrors.ErrExternalAuthorizationDenied)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestExternalAuthorizer_ForwardsPrincipalNotIdentityHeaders(t *testing.T) {
+	var received struct {
+		Headers   map[string][]string    `json:"headers"`
+		Principal map[string]interface{} `json:"principal"`
+	}
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		json.NewEncoder(w).Encode(map[string]interface{}{"allow": true})
+	}))
+	defer service.Close()
+
+	request := newExternalRequest("compliance")
+	request.Headers["X-Gateway-User"] = []string{"admin"}
+	request.Headers["X-Identity"] = []string{"forged.token"}
+	request.Principal = &entities.Principal{Subject: "billing", AuthType: entities.AuthTypeAPIKey, KeyID: "k1"}
+
+	tests := []struct {
+		name           string
+		forwardHeaders []string
+	}{
+		{name: "all headers"},
+		{name: "listed headers", forwardHeaders: []string{"X-Team", "X-Gateway-User", "X-Identity"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorizer := auth.NewExternalAuthorizer(logger.New("test"), "X-Identity")
+			cfg := &entities.ExternalAuthConfig{Endpoint: service.URL, ForwardHeaders: tt.forwardHeaders}
+
+			_, err := authorizer.Validate(context.Background(), request, cfg)
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"compliance"}, received.Headers["X-Team"])
+			assert.NotContains(t, received.Headers, "X-Gateway-User")
+			assert.NotContains(t, received.Headers, "X-Identity")
+			assert.Equal(t, "billing", received.Principal["subject"])
+			assert.Equal(t, entities.AuthTypeAPIKey, received.Principal["auth_type"])
+			assert.Equal(t, "k1", received.Principal["key_id"])
+		})
+	}
+}
+
+func TestExternalAuthorizer_ServiceFailure(t *testing.T) {
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer service.Close()
+
+	authorizer := auth.NewExternalAuthorizer(logger.New("test"), "")
+
+	_, err := authorizer.Validate(context.Background(), newExternalRequest("compliance"), &entities.ExternalAuthConfig{Endpoint: service.URL})
+	assert.ErrorIs(t, err, domainErrors.ErrExternalAuthorizationUnavailable)
+
+	principal, err := authorizer.Validate(context.Background(), newExternalRequest("compliance"), &entities.ExternalAuthConfig{Endpoint: service.URL, FailOpen: true})
+	require.NoError(t, err)
+	assert.Equal(t, entities.AuthTypeExternal, principal.AuthType)
+	assert.Equal(t, true, principal.Claims["fail_open"])
+}
@@ -0,0 +1,161 @@
+package auth_test
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var hmacConfig = &entities.HMACConfig{
+	Credentials: []entities.HMACCredential{
+		{ID: "acme", Secret: "acme-secret", Owner: "acme-corp", Scopes: []string{"webhooks:write"}},
+	},
+	SignedHeaders: []string{"content-type"},
+	MaxClockSkew:  time.Minute,
+}
+
+func newSignedRequest() *entities.HTTPRequest {
+	return &entities.HTTPRequest{
+		Method:   "POST",
+		Host:     "gateway.internal",
+		Path:     "/api/orders/webhooks",
+		RawQuery: "b=2&a=1&a=0",
+		Headers:  map[string][]string{"Content-Type": {"application/json"}},
+		Body:     []byte(`{"event":"order.created"}`),
+	}
+}
+
+// signRequest signs a request the way a client would, after which the request must not change
+func signRequest(req *entities.HTTPRequest, credential, secret string, signedAt time.Time, nonce string) {
+	signedHeaders := []string{"content-type", "host"}
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	signature := auth.SignHMAC(secret, auth.StringToSign(req, signedHeaders, timestamp, nonce))
+
+	req.Headers[auth.HMACTimestampHeader] = []string{timestamp}
+	req.Headers[auth.HMACNonceHeader] = []string{nonce}
+	req.Headers["Authorization"] = []string{fmt.Sprintf("%s Credential=%s, SignedHeaders=content-type;host, Signature=%s",
+		auth.HMACAlgorithm, credential, hex.EncodeToString(signature))}
+}
+
+func TestHMACValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(req *entities.HTTPRequest)
+		wantErr error
+	}{
+		{
+			name: "valid signature",
+			prepare: func(req *entities.HTTPRequest) {
+				signRequest(req, "acme", "acme-secret", time.Now(), "nonce-valid")
+			},
+		},
+		{
+			name:    "missing signature",
+			prepare: func(req *entities.HTTPRequest) {},
+			wantErr: domainErrors.ErrMissingSignature,
+		},
+		{
+			name: "unknown credential",
+			prepare: func(req *entities.HTTPRequest) {
+				signRequest(req, "other", "acme-secret", time.Now(), "nonce-unknown")
+			},
+			wantErr: domainErrors.ErrInvalidSignature,
+		},
+		{
+			name: "wrong secret",
+			prepare: func(req *entities.HTTPRequest) {
+				signRequest(req, "acme", "guessed", time.Now(), "nonce-secret")
+			},
+			wantErr: domainErrors.ErrInvalidSignature,
+		},
+		{
+			name: "tampered body",
+			prepare: func(req *entities.HTTPRequest) {
+				signRequest(req, "acme", "acme-secret", time.Now(), "nonce-body")
+				req.Body = []byte(`{"event":"order.cancelled"}`)
+			},
+			wantErr: domainErrors.ErrInvalidSignature,
+		},
+		{
+			name: "tampered query",
+			prepare: func(req *entities.HTTPRequest) {
+				signRequest(req, "acme", "acme-secret", time.Now(), "nonce-query")
+				req.RawQuery = "a=1&b=3"
+			},
+			wantErr: domainErrors.ErrInvalidSignature,
+		},
+		{
+			name: "reordered query is the same request",
+			prepare: func(req *entities.HTTPRequest) {
+				signRequest(req, "acme", "acme-secret", time.Now(), "nonce-order")
+				req.RawQuery = "a=0&a=1&b=2"
+			},
+		},
+		{
+			name: "stale timestamp",
+			prepare: func(req *entities.HTTPRequest) {
+				signRequest(req, "acme", "acme-secret", time.Now().Add(-2*time.Minute), "nonce-stale")
+			},
+			wantErr: domainErrors.ErrSignatureExpired,
+		},
+		{
+			name: "timestamp in the future",
+			prepare: func(req *entities.HTTPRequest) {
+				signRequest(req, "acme", "acme-secret", time.Now().Add(2*time.Minute), "nonce-future")
+			},
+			wantErr: domainErrors.ErrSignatureExpired,
+		},
+		{
+			name: "required header not signed",
+			prepare: func(req *entities.HTTPRequest) {
+				signRequest(req, "acme", "acme-secret", time.Now(), "nonce-headers")
+				req.Headers["Authorization"] = []string{auth.HMACAlgorithm + " Credential=acme, SignedHeaders=host, Signature=00"}
+			},
+			wantErr: domainErrors.ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := auth.NewHMACValidator(logger.New("test"), repositories.NewMemoryNonceStore())
+			req := newSignedRequest()
+			tt.prepare(req)
+
+			principal, err := validator.Validate(context.Background(), req, hmacConfig)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, principal)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "acme-corp", principal.Subject)
+			assert.Equal(t, "acme", principal.KeyID)
+			assert.Equal(t, entities.AuthTypeHMAC, principal.AuthType)
+			assert.Equal(t, []string{"webhooks:write"}, principal.Scopes)
+		})
+	}
+}
+
+func TestHMACValidator_RejectsReplay(t *testing.T) {
+	validator := auth.NewHMACValidator(logger.New("test"), repositories.NewMemoryNonceStore())
+	req := newSignedRequest()
+	signRequest(req, "acme", "acme-secret", time.Now(), "nonce-replay")
+
+	_, err := validator.Validate(context.Background(), req, hmacConfig)
+	require.NoError(t, err)
+
+	_, err = validator.Validate(context.Background(), req, hmacConfig)
+	assert.ErrorIs(t, err, domainErrors.ErrSignatureReplayed)
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// unknownUserHash is compared against when a user name is not in the file so
+// that unknown and known users take the same time to reject
+const unknownUserHash = "$2a$10$VdGD1.uH.ByVbAEXLQhHPe4ebjxLIhqXr5bdG0cV6cy4xjMcjaAG6"
+
+var errUnsupportedPasswordHash = errors.New("unsupported password hash, expected bcrypt or argon2")
+
+// argon2Hash is a password hash in the PHC string format
+//
+//	$argon2id$v=19$m=65536,t=3,p=4$<base64 salt>$<base64 hash>
+type argon2Hash struct {
+	variant string
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+// LoadHtpasswd reads an htpasswd file into a map of user names to password hashes
+func LoadHtpasswd(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read htpasswd file: %w", err)
+	}
+	defer file.Close()
+
+	users, err := ParseHtpasswd(file)
+	if err != nil {
+		return nil, fmt.Errorf("htpasswd file %s: %w", path, err)
+	}
+	return users, nil
+}
+
+// ParseHtpasswd reads "user:hash" lines, skipping blank lines and comments.
+// Only bcrypt and argon2 hashes are accepted, the legacy MD5, SHA1 and crypt
+// formats are rejected instead of silently never matching.
+func ParseHtpasswd(r io.Reader) (map[string]string, error) {
+	users := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		user, hash, found := strings.Cut(entry, ":")
+		if !found || user == "" || hash == "" {
+			return nil, fmt.Errorf("line %d: expected user:hash", line)
+		}
+		if _, exists := users[user]; exists {
+			return nil, fmt.Errorf("line %d: duplicate user %q", line, user)
+		}
+		if err := checkPasswordHashFormat(hash); err != nil {
+			return nil, fmt.Errorf("line %d: user %q: %w", line, user, err)
+		}
+		users[user] = hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// checkPassword reports whether password matches a bcrypt or argon2 hash
+func checkPassword(hash, password string) bool {
+	if strings.HasPrefix(hash, "$argon2") {
+		parsed, err := parseArgon2Hash(hash)
+		if err != nil {
+			return false
+		}
+		return subtle.ConstantTimeCompare(parsed.derive(password), parsed.key) == 1
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func checkPasswordHashFormat(hash string) error {
+	if strings.HasPrefix(hash, "$argon2") {
+		_, err := parseArgon2Hash(hash)
+		return err
+	}
+	if strings.HasPrefix(hash, "$2") {
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return fmt.Errorf("invalid bcrypt hash: %w", err)
+		}
+		return nil
+	}
+	return errUnsupportedPasswordHash
+}
+
+func parseArgon2Hash(hash string) (*argon2Hash, error) {
+	// "", variant, version, params, salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || (parts[1] != "argon2id" && parts[1] != "argon2i") {
+		return nil, errUnsupportedPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, fmt.Errorf("unsupported argon2 version %q", parts[2])
+	}
+
+	parsed := &argon2Hash{variant: parts[1]}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &parsed.memory, &parsed.time, &parsed.threads); err != nil {
+		return nil, fmt.Errorf("invalid argon2 parameters %q", parts[3])
+	}
+	if parsed.memory == 0 || parsed.time == 0 || parsed.threads == 0 {
+		return nil, fmt.Errorf("invalid argon2 parameters %q", parts[3])
+	}
+
+	var err error
+	if parsed.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("invalid argon2 salt: %w", err)
+	}
+	if parsed.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(parsed.key) == 0 {
+		return nil, errors.New("invalid argon2 hash")
+	}
+	return parsed, nil
+}
+
+func (h *argon2Hash) derive(password string) []byte {
+	if h.variant == "argon2i" {
+		return argon2.Key([]byte(password), h.salt, h.time, h.memory, h.threads, uint32(len(h.key)))
+	}
+	return argon2.IDKey([]byte(password), h.salt, h.time, h.memory, h.threads, uint32(len(h.key)))
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	defaultIdentityIssuer   = "api-gateway"
+	defaultIdentityTokenTTL = time.Minute
+)
+
+// IdentitySignerOptions configures the identity tokens issued to backends.
+// HS256 signs with Secret, RS256 and ES256 with the PEM encoded PrivateKey.
+type IdentitySignerOptions struct {
+	Issuer     string
+	TTL        time.Duration
+	Algorithm  string
+	KeyID      string
+	Secret     string
+	PrivateKey string
+}
+
+// JWTIdentitySigner signs identity tokens as JWTs
+type JWTIdentitySigner struct {
+	issuer string
+	ttl    time.Duration
+	keyID  string
+	method jwt.SigningMethod
+	key    interface{}
+}
+
+func NewIdentitySigner(opts IdentitySignerOptions) (ports.IdentitySigner, error) {
+	signer := &JWTIdentitySigner{
+		issuer: opts.Issuer,
+		ttl:    opts.TTL,
+		keyID:  opts.KeyID,
+	}
+	if signer.issuer == "" {
+		signer.issuer = defaultIdentityIssuer
+	}
+	if signer.ttl <= 0 {
+		signer.ttl = defaultIdentityTokenTTL
+	}
+
+	var err error
+	switch opts.Algorithm {
+	case "", entities.JWTAlgorithmHS256:
+		if opts.Secret == "" {
+			return nil, domainErrors.ErrJWTMissingKeyMaterial
+		}
+		signer.method, signer.key = jwt.SigningMethodHS256, []byte(opts.Secret)
+	case entities.JWTAlgorithmRS256:
+		signer.method = jwt.SigningMethodRS256
+		signer.key, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(opts.PrivateKey))
+	case entities.JWTAlgorithmES256:
+		signer.method = jwt.SigningMethodES256
+		signer.key, err = jwt.ParseECPrivateKeyFromPEM([]byte(opts.PrivateKey))
+	default:
+		return nil, domainErrors.ErrJWTUnsupportedAlgorithm
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s identity signing key: %w", opts.Algorithm, err)
+	}
+	return signer, nil
+}
+
+func (s *JWTIdentitySigner) Sign(principal *entities.Principal, audience string) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":       s.issuer,
+		"sub":       principal.Subject,
+		"aud":       audience,
+		"iat":       now.Unix(),
+		"nbf":       now.Unix(),
+		"exp":       now.Add(s.ttl).Unix(),
+		"jti":       hex.EncodeToString(jti),
+		"auth_type": principal.AuthType,
+	}
+	if len(principal.Scopes) > 0 {
+		claims["scope"] = strings.Join(principal.Scopes, " ")
+	}
+	if len(principal.Roles) > 0 {
+		claims["roles"] = principal.Roles
+	}
+	if principal.KeyID != "" {
+		claims["key_id"] = principal.KeyID
+	}
+
+	token := jwt.NewWithClaims(s.method, claims)
+	if s.keyID != "" {
+		token.Header["kid"] = s.keyID
+	}
+	return token.SignedString(s.key)
+}
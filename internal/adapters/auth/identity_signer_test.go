@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/domain/entities"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var identityPrincipal = &entities.Principal{
+	Subject:  "billing",
+	AuthType: entities.AuthTypeAPIKey,
+	KeyID:    "k1",
+	Scopes:   []string{"orders:read", "orders:write"},
+	Roles:    []string{"service"},
+}
+
+func TestIdentitySigner_HS256(t *testing.T) {
+	signer, err := auth.NewIdentitySigner(auth.IdentitySignerOptions{Secret: "backend-shared-secret", KeyID: "gw-1", TTL: 30 * time.Second})
+	require.NoError(t, err)
+
+	signed, err := signer.Sign(identityPrincipal, "orders")
+	require.NoError(t, err)
+
+	token, err := jwt.Parse(signed, func(token *jwt.Token) (interface{}, error) {
+		return []byte("backend-shared-secret"), nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithAudience("orders"), jwt.WithIssuer("api-gateway"))
+	require.NoError(t, err)
+
+	claims := token.Claims.(jwt.MapClaims)
+	assert.Equal(t, "gw-1", token.Header["kid"])
+	assert.Equal(t, "billing", claims["sub"])
+	assert.Equal(t, "orders:read orders:write", claims["scope"])
+	assert.Equal(t, []interface{}{"service"}, claims["roles"])
+	assert.Equal(t, "k1", claims["key_id"])
+	assert.Equal(t, entities.AuthTypeAPIKey, claims["auth_type"])
+	assert.Equal(t, float64(30), claims["exp"].(float64)-claims["iat"].(float64))
+	assert.NotEmpty(t, claims["jti"])
+}
+
+func TestIdentitySigner_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	signer, err := auth.NewIdentitySigner(auth.IdentitySignerOptions{
+		Algorithm:  entities.JWTAlgorithmES256,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})),
+	})
+	require.NoError(t, err)
+
+	signed, err := signer.Sign(identityPrincipal, "orders")
+	require.NoError(t, err)
+
+	_, err = jwt.Parse(signed, func(token *jwt.Token) (interface{}, error) {
+		return &key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	assert.NoError(t, err)
+}
+
+func TestIdentitySigner_InvalidOptions(t *testing.T) {
+	_, err := auth.NewIdentitySigner(auth.IdentitySignerOptions{Algorithm: entities.JWTAlgorithmHS256})
+	assert.Error(t, err)
+
+	_, err = auth.NewIdentitySigner(auth.IdentitySignerOptions{Algorithm: entities.JWTAlgorithmRS256, PrivateKey: "not a key"})
+	assert.Error(t, err)
+
+	_, err = auth.NewIdentitySigner(auth.IdentitySignerOptions{Algorithm: "none", Secret: "s"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultIntrospectionCacheTTL         = time.Minute
+	defaultIntrospectionNegativeCacheTTL = 10 * time.Second
+	defaultIntrospectionTimeout          = 5 * time.Second
+)
+
+// introspectionResponse holds the RFC 7662 fields the gateway relies on
+type introspectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope"`
+	ClientID  string `json:"client_id"`
+	Username  string `json:"username"`
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+	Issuer    string `json:"iss"`
+}
+
+// IntrospectionValidator validates opaque tokens against an RFC 7662
+// introspection endpoint and caches active and inactive results
+type IntrospectionValidator struct {
+	httpClient *http.Client
+	logger     logger.Logger
+	cache      *resultCache
+}
+
+func NewIntrospectionValidator(log logger.Logger) *IntrospectionValidator {
+	return &IntrospectionValidator{
+		httpClient: &http.Client{},
+		logger:     log.With("component", "introspection_validator"),
+		cache:      newResultCache(defaultResultCacheSize),
+	}
+}
+
+func (v *IntrospectionValidator) Validate(ctx context.Context, token string, cfg *entities.IntrospectionConfig) (*entities.Principal, error) {
+	if cfg == nil {
+		return nil, domainErrors.ErrAuthPolicyMissingIntrospectionConfig
+	}
+
+	// The cache holds what the endpoint said about the token, routes sharing
+	// the endpoint differ in the scopes they require
+	cacheKey := introspectionCacheKey(cfg.Endpoint, token)
+	if result, ok := v.cache.get(cacheKey); ok {
+		v.logger.Debug("Introspection cache hit", "active", result.err == nil)
+		if result.err != nil {
+			return nil, result.err
+		}
+		return requireScopes(result.principal, cfg)
+	}
+
+	response, err := v.introspect(ctx, token, cfg)
+	if err != nil {
+		// Transport failures are not cached so the next request retries
+		v.logger.Error("Token introspection failed", "endpoint", cfg.Endpoint, "error", err)
+		return nil, domainErrors.ErrIntrospectionUnavailable
+	}
+
+	principal, err := toIntrospectionPrincipal(response)
+	v.cache.put(cacheKey, principal, err, cacheExpiry(response, err, cfg))
+	if err != nil {
+		return nil, err
+	}
+	return requireScopes(principal, cfg)
+}
+
+func (v *IntrospectionValidator) introspect(ctx context.Context, token string, cfg *entities.IntrospectionConfig) (*introspectionResponse, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultIntrospectionTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if cfg.ClientID != "" {
+		req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call introspection endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspection endpoint returned status %d", resp.StatusCode)
+	}
+
+	var response introspectionResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&response); err != nil {
+		return nil, fmt.Errorf("invalid introspection response: %w", err)
+	}
+	return &response, nil
+}
+
+func toIntrospectionPrincipal(response *introspectionResponse) (*entities.Principal, error) {
+	if !response.Active {
+		return nil, domainErrors.ErrTokenInactive
+	}
+	if response.ExpiresAt != 0 && time.Now().After(time.Unix(response.ExpiresAt, 0)) {
+		return nil, domainErrors.ErrTokenExpired
+	}
+
+	principal := &entities.Principal{
+		Subject:  response.Subject,
+		AuthType: entities.AuthTypeOAuth2Introspection,
+		Scopes:   strings.Fields(response.Scope),
+		Claims: map[string]interface{}{
+			"client_id": response.ClientID,
+			"username":  response.Username,
+			"iss":       response.Issuer,
+			"scope":     response.Scope,
+		},
+	}
+	if principal.Subject == "" {
+		principal.Subject = response.ClientID
+	}
+	return principal, nil
+}
+
+// requireScopes rejects principals without the scopes the policy requires
+func requireScopes(principal *entities.Principal, cfg *entities.IntrospectionConfig) (*entities.Principal, error) {
+	for _, scope := range cfg.RequiredScopes {
+		if !principal.HasScope(scope) {
+			return nil, domainErrors.ErrTokenMissingScope
+		}
+	}
+	return principal, nil
+}
+
+// cacheExpiry caches active tokens for the positive TTL but never past their exp,
+// and rejected tokens for the negative TTL
+func cacheExpiry(response *introspectionResponse, err error, cfg *entities.IntrospectionConfig) time.Time {
+	now := time.Now()
+	if err != nil {
+		ttl := cfg.NegativeCacheTTL
+		if ttl <= 0 {
+			ttl = defaultIntrospectionNegativeCacheTTL
+		}
+		return now.Add(ttl)
+	}
+
+	ttl := cfg.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultIntrospectionCacheTTL
+	}
+	expiresAt := now.Add(ttl)
+	if response.ExpiresAt != 0 && time.Unix(response.ExpiresAt, 0).Before(expiresAt) {
+		expiresAt = time.Unix(response.ExpiresAt, 0)
+	}
+	return expiresAt
+}
+
+// introspectionCacheKey avoids keeping raw bearer tokens in memory as map keys
+func introspectionCacheKey(endpoint, token string) string {
+	sum := sha256.Sum256([]byte(endpoint + "\x00" + token))
+	return hex.EncodeToString(sum[:])
+}
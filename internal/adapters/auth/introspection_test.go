@@ -0,0 +1,187 @@
+package auth_test
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIntrospectionServer(calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "gateway" || clientSecret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		response := map[string]interface{}{"active": false}
+		switch r.PostForm.Get("token") {
+		case "active-token":
+			response = map[string]interface{}{
+				"active": true,
+				"sub":    "partner-7",
+				"scope":  "orders:read orders:write",
+				"exp":    time.Now().Add(time.Hour).Unix(),
+			}
+		case "expired-token":
+			response = map[string]interface{}{
+				"active": true,
+				"sub":    "partner-7",
+				"exp":    time.Now().Add(-time.Minute).Unix(),
+			}
+		case "read-only-token":
+			response = map[string]interface{}{
+				"active": true,
+				"sub":    "partner-8",
+				"scope":  "orders:read",
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestIntrospectionValidator_Validate(t *testing.T) {
+	var calls int32
+	server := newIntrospectionServer(&calls)
+	defer server.Close()
+
+	cfg := &entities.IntrospectionConfig{
+		Endpoint:         server.URL,
+		ClientID:         "gateway",
+		ClientSecret:     "s3cret",
+		RequiredScopes:   []string{"orders:write"},
+		CacheTTL:         time.Minute,
+		NegativeCacheTTL: time.Minute,
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "active token", token: "active-token"},
+		{name: "inactive token", token: "revoked-token", wantErr: domainErrors.ErrTokenInactive},
+		{name: "expired token", token: "expired-token", wantErr: domainErrors.ErrTokenExpired},
+		{name: "missing scope", token: "read-only-token", wantErr: domainErrors.ErrTokenMissingScope},
+	}
+
+	validator := auth.NewIntrospectionValidator(logger.New("test"))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := validator.Validate(context.Background(), tt.token, cfg)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, principal)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "partner-7", principal.Subject)
+			assert.ElementsMatch(t, []string{"orders:read", "orders:write"}, principal.Scopes)
+		})
+	}
+}
+
+func TestIntrospectionValidator_CachesResults(t *testing.T) {
+	var calls int32
+	server := newIntrospectionServer(&calls)
+	defer server.Close()
+
+	cfg := &entities.IntrospectionConfig{
+		Endpoint:     server.URL,
+		ClientID:     "gateway",
+		ClientSecret: "s3cret",
+	}
+	validator := auth.NewIntrospectionValidator(logger.New("test"))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := validator.Validate(ctx, "active-token", cfg)
+		require.NoError(t, err)
+		_, err = validator.Validate(ctx, "revoked-token", cfg)
+		require.ErrorIs(t, err, domainErrors.ErrTokenInactive)
+	}
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestIntrospectionValidator_CachedResultsRequireScopes(t *testing.T) {
+	var calls int32
+	server := newIntrospectionServer(&calls)
+	defer server.Close()
+
+	open := &entities.IntrospectionConfig{Endpoint: server.URL, ClientID: "gateway", ClientSecret: "s3cret"}
+	protected := &entities.IntrospectionConfig{Endpoint: server.URL, ClientID: "gateway", ClientSecret: "s3cret", RequiredScopes: []string{"orders:write"}}
+	validator := auth.NewIntrospectionValidator(logger.New("test"))
+	ctx := context.Background()
+
+	_, err := validator.Validate(ctx, "read-only-token", open)
+	require.NoError(t, err)
+	_, err = validator.Validate(ctx, "read-only-token", protected)
+	assert.ErrorIs(t, err, domainErrors.ErrTokenMissingScope, "a token cached for one route does not skip the scopes of another")
+
+	validator = auth.NewIntrospectionValidator(logger.New("test"))
+	_, err = validator.Validate(ctx, "read-only-token", protected)
+	assert.ErrorIs(t, err, domainErrors.ErrTokenMissingScope)
+	_, err = validator.Validate(ctx, "read-only-token", open)
+	assert.NoError(t, err, "a missing scope on one route does not reject the token on another")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestIntrospectionValidator_CacheExpiry(t *testing.T) {
+	var calls int32
+	server := newIntrospectionServer(&calls)
+	defer server.Close()
+
+	cfg := &entities.IntrospectionConfig{
+		Endpoint:         server.URL,
+		ClientID:         "gateway",
+		ClientSecret:     "s3cret",
+		NegativeCacheTTL: 20 * time.Millisecond,
+	}
+	validator := auth.NewIntrospectionValidator(logger.New("test"))
+	ctx := context.Background()
+
+	_, err := validator.Validate(ctx, "revoked-token", cfg)
+	require.ErrorIs(t, err, domainErrors.ErrTokenInactive)
+	_, err = validator.Validate(ctx, "revoked-token", cfg)
+	require.ErrorIs(t, err, domainErrors.ErrTokenInactive)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, err = validator.Validate(ctx, "revoked-token", cfg)
+	require.ErrorIs(t, err, domainErrors.ErrTokenInactive)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestIntrospectionValidator_EndpointFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	validator := auth.NewIntrospectionValidator(logger.New("test"))
+
+	_, err := validator.Validate(context.Background(), "active-token", &entities.IntrospectionConfig{Endpoint: server.URL})
+	assert.ErrorIs(t, err, domainErrors.ErrIntrospectionUnavailable)
+}
@@ -0,0 +1,304 @@
+package auth
+
+import (
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultJWKSRefreshInterval = 15 * time.Minute
+	defaultJWKSCacheTTL        = time.Hour
+	// minJWKSRefreshGap bounds how often an unknown kid may trigger a refetch
+	minJWKSRefreshGap = 30 * time.Second
+	jwksFetchTimeout  = 10 * time.Second
+)
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// JWKSProvider keeps the keys of a JWKS source in memory, refreshing them in
+// the background and falling back to the last known good set when a fetch fails
+type JWKSProvider struct {
+	source          string
+	isURL           bool
+	refreshInterval time.Duration
+	cacheTTL        time.Duration
+	httpClient      *http.Client
+	logger          logger.Logger
+
+	mu          sync.RWMutex
+	keys        []parsedKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+
+	refreshMu sync.Mutex
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+func NewJWKSProvider(cfg *entities.JWKSConfig, log logger.Logger) *JWKSProvider {
+	refreshInterval := cfg.RefreshInterval
+	if refreshInterval <= 0 {
+		refreshInterval = defaultJWKSRefreshInterval
+	}
+	cacheTTL := cfg.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = defaultJWKSCacheTTL
+	}
+
+	return &JWKSProvider{
+		source:          cfg.Source(),
+		isURL:           cfg.URL != "",
+		refreshInterval: refreshInterval,
+		cacheTTL:        cacheTTL,
+		httpClient:      &http.Client{Timeout: jwksFetchTimeout},
+		logger:          log.With("component", "jwks_provider", "source", cfg.Source()),
+		stop:            make(chan struct{}),
+	}
+}
+
+// Start loads the key set once and keeps refreshing it until Close is called
+func (p *JWKSProvider) Start(ctx context.Context) {
+	if err := p.Refresh(ctx); err != nil {
+		p.logger.Warn("Initial JWKS load failed, will retry in background", "error", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(p.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.Refresh(context.Background()); err != nil {
+					p.logger.Warn("Background JWKS refresh failed, keeping last known keys", "error", err)
+				}
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Keys returns the verification keys, refreshing first when the cache has
+// expired or the requested kid is unknown
+func (p *JWKSProvider) Keys(ctx context.Context, kid string) ([]parsedKey, error) {
+	keys, fetchedAt, attemptedAt := p.snapshot()
+
+	stale := time.Since(fetchedAt) > p.cacheTTL
+	unknownKid := kid != "" && !containsKid(keys, kid)
+	if (stale || unknownKid) && time.Since(attemptedAt) > minJWKSRefreshGap {
+		if err := p.Refresh(ctx); err != nil {
+			p.logger.Warn("JWKS refresh failed, using last known keys",
+				"error", err,
+				"kid", kid,
+				"keys_count", len(keys),
+			)
+		}
+		keys, _, _ = p.snapshot()
+	}
+
+	if len(keys) == 0 {
+		return nil, domainErrors.ErrJWKSUnavailable
+	}
+	return keys, nil
+}
+
+// Refresh fetches and parses the key set, replacing the cached keys on success only
+func (p *JWKSProvider) Refresh(ctx context.Context) error {
+	p.refreshMu.Lock()
+	defer p.refreshMu.Unlock()
+
+	p.mu.Lock()
+	p.attemptedAt = time.Now()
+	p.mu.Unlock()
+
+	content, err := p.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := p.parseJWKS(content)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return errors.New("jwks contains no usable signing keys")
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.fetchedAt = time.Now()
+	p.mu.Unlock()
+
+	p.logger.Info("JWKS loaded", "keys_count", len(keys))
+	return nil
+}
+
+func (p *JWKSProvider) Close() error {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+	return nil
+}
+
+func (p *JWKSProvider) snapshot() ([]parsedKey, time.Time, time.Time) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.keys, p.fetchedAt, p.attemptedAt
+}
+
+func (p *JWKSProvider) fetch(ctx context.Context) ([]byte, error) {
+	if !p.isURL {
+		content, err := os.ReadFile(p.source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwks file: %w", err)
+		}
+		return content, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jwks request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks response: %w", err)
+	}
+	return content, nil
+}
+
+func containsKid(keys []parsedKey, kid string) bool {
+	for _, key := range keys {
+		if key.id == kid {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJWKS converts the RSA and EC signing keys of a key set; other key
+// types and encryption keys are skipped. Keys that fail to parse are logged
+// and skipped too, so one odd key does not discard the rest of the set.
+func (p *JWKSProvider) parseJWKS(content []byte) ([]parsedKey, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("invalid jwks document: %w", err)
+	}
+
+	keys := make([]parsedKey, 0, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		switch jwk.Kty {
+		case "RSA":
+			if jwk.Alg != "" && jwk.Alg != entities.JWTAlgorithmRS256 {
+				continue
+			}
+			key, err := rsaKeyFromJWK(jwk)
+			if err != nil {
+				p.logger.Warn("Skipping invalid JWKS key", "kid", jwk.Kid, "error", err)
+				continue
+			}
+			keys = append(keys, parsedKey{id: jwk.Kid, algorithm: entities.JWTAlgorithmRS256, key: key})
+		case "EC":
+			if jwk.Crv != "P-256" || (jwk.Alg != "" && jwk.Alg != entities.JWTAlgorithmES256) {
+				continue
+			}
+			key, err := ecKeyFromJWK(jwk)
+			if err != nil {
+				p.logger.Warn("Skipping invalid JWKS key", "kid", jwk.Kid, "error", err)
+				continue
+			}
+			keys = append(keys, parsedKey{id: jwk.Kid, algorithm: entities.JWTAlgorithmES256, key: key})
+		}
+	}
+	return keys, nil
+}
+
+func rsaKeyFromJWK(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus for key %q: %w", jwk.Kid, err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent for key %q: %w", jwk.Kid, err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 2 || exponent.Int64() > math.MaxInt32 {
+		return nil, fmt.Errorf("unsupported modulus or exponent for key %q", jwk.Kid)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
+
+func ecKeyFromJWK(jwk jsonWebKey) (*ecdsa.PublicKey, error) {
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x coordinate for key %q: %w", jwk.Kid, err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y coordinate for key %q: %w", jwk.Kid, err)
+	}
+
+	key := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+	if _, err := key.ECDH(); err != nil {
+		return nil, fmt.Errorf("key %q is not on curve P-256: %w", jwk.Kid, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,231 @@
+package auth_test
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jwksServer serves a key set that tests can rotate or break at will
+type jwksServer struct {
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	failing bool
+	hits    int
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hits++
+	if s.failing {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jwksDocument(s.keys))
+}
+
+func (s *jwksServer) set(keys map[string]*rsa.PrivateKey, failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+	s.failing = failing
+}
+
+func jwksDocument(keys map[string]*rsa.PrivateKey) []byte {
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, map[string]string{
+			"kid": kid,
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	content, _ := json.Marshal(set)
+	return content
+}
+
+func rsaToken(t *testing.T, kid string, key *rsa.PrivateKey) string {
+	return signToken(t, jwt.SigningMethodRS256, kid, key, jwt.MapClaims{
+		"sub": "user-42",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+}
+
+func TestJWTValidator_JWKSURL(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := &jwksServer{keys: map[string]*rsa.PrivateKey{"old": oldKey}}
+	server := httptest.NewServer(jwks)
+	defer server.Close()
+
+	cfg := &entities.JWTConfig{
+		JWKS: &entities.JWKSConfig{
+			URL:             server.URL,
+			RefreshInterval: time.Hour,
+			CacheTTL:        time.Hour,
+		},
+	}
+
+	validator := auth.NewJWTValidator(logger.New("test"))
+	defer validator.Close()
+	ctx := context.Background()
+
+	principal, err := validator.Validate(ctx, rsaToken(t, "old", oldKey), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", principal.Subject)
+
+	// The identity provider rotates keys; the refresh is throttled right after the initial load
+	jwks.set(map[string]*rsa.PrivateKey{"new": newKey}, false)
+	_, err = validator.Validate(ctx, rsaToken(t, "new", newKey), cfg)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidToken)
+}
+
+func TestJWKSProvider_RotationAndFallback(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := &jwksServer{keys: map[string]*rsa.PrivateKey{"old": oldKey}}
+	server := httptest.NewServer(jwks)
+	defer server.Close()
+
+	provider := auth.NewJWKSProvider(&entities.JWKSConfig{URL: server.URL}, logger.New("test"))
+	defer provider.Close()
+	ctx := context.Background()
+
+	require.NoError(t, provider.Refresh(ctx))
+	keys, err := provider.Keys(ctx, "old")
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	// A failing fetch keeps the last known good key set
+	jwks.set(map[string]*rsa.PrivateKey{"new": newKey}, true)
+	assert.Error(t, provider.Refresh(ctx))
+	keys, err = provider.Keys(ctx, "old")
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	// Once the endpoint recovers the rotated key set replaces the old one
+	jwks.set(map[string]*rsa.PrivateKey{"old": oldKey, "new": newKey}, false)
+	require.NoError(t, provider.Refresh(ctx))
+	keys, err = provider.Keys(ctx, "new")
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestJWKSProvider_BackgroundRefresh(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := &jwksServer{keys: map[string]*rsa.PrivateKey{"k1": key}}
+	server := httptest.NewServer(jwks)
+	defer server.Close()
+
+	provider := auth.NewJWKSProvider(&entities.JWKSConfig{
+		URL:             server.URL,
+		RefreshInterval: 20 * time.Millisecond,
+	}, logger.New("test"))
+	provider.Start(context.Background())
+	defer provider.Close()
+
+	assert.Eventually(t, func() bool {
+		jwks.mu.Lock()
+		defer jwks.mu.Unlock()
+		return jwks.hits >= 3
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestJWKSProvider_File(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksDocument(map[string]*rsa.PrivateKey{"file-key": key}), 0o600))
+
+	validator := auth.NewJWTValidator(logger.New("test"))
+	defer validator.Close()
+
+	cfg := &entities.JWTConfig{JWKS: &entities.JWKSConfig{File: path}}
+	principal, err := validator.Validate(context.Background(), rsaToken(t, "file-key", key), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", principal.Subject)
+}
+
+func TestJWKSProvider_SkipsInvalidKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(jwksDocument(map[string]*rsa.PrivateKey{"good": key}), &set))
+	set.Keys = append(set.Keys,
+		map[string]string{"kid": "bad-modulus", "kty": "RSA", "n": "not base64!", "e": "AQAB"},
+		map[string]string{"kid": "bad-exponent", "kty": "RSA", "n": set.Keys[0]["n"], "e": ""},
+		map[string]string{"kid": "off-curve", "kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"},
+	)
+	content, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	provider := auth.NewJWKSProvider(&entities.JWKSConfig{File: path}, logger.New("test"))
+	defer provider.Close()
+
+	keys, err := provider.Keys(context.Background(), "good")
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	// A set without any usable key is still an error
+	set.Keys = set.Keys[1:]
+	content, err = json.Marshal(set)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	provider = auth.NewJWKSProvider(&entities.JWKSConfig{File: path}, logger.New("test"))
+	defer provider.Close()
+
+	_, err = provider.Keys(context.Background(), "good")
+	assert.ErrorIs(t, err, domainErrors.ErrJWKSUnavailable)
+}
+
+func TestJWKSProvider_Unavailable(t *testing.T) {
+	jwks := &jwksServer{failing: true}
+	server := httptest.NewServer(jwks)
+	defer server.Close()
+
+	provider := auth.NewJWKSProvider(&entities.JWKSConfig{URL: server.URL}, logger.New("test"))
+	defer provider.Close()
+
+	_, err := provider.Keys(context.Background(), "any")
+	assert.ErrorIs(t, err, domainErrors.ErrJWKSUnavailable)
+}
@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTValidator verifies bearer tokens against the keys of a JWT auth policy
+type JWTValidator struct {
+	logger logger.Logger
+
+	mu        sync.RWMutex
+	keys      map[*entities.JWTConfig][]parsedKey
+	providers map[string]*JWKSProvider
+}
+
+type parsedKey struct {
+	id        string
+	algorithm string
+	key       interface{}
+}
+
+func NewJWTValidator(log logger.Logger) *JWTValidator {
+	return &JWTValidator{
+		logger:    log.With("component", "jwt_validator"),
+		keys:      make(map[*entities.JWTConfig][]parsedKey),
+		providers: make(map[string]*JWKSProvider),
+	}
+}
+
+// Validate checks signature, exp, nbf, iss and aud and returns the token's principal
+func (v *JWTValidator) Validate(ctx context.Context, token string, cfg *entities.JWTConfig) (*entities.Principal, error) {
+	if cfg == nil {
+		return nil, domainErrors.ErrAuthPolicyMissingJWTConfig
+	}
+
+	keys, err := v.parsedKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.JWKS != nil {
+		jwksKeys, err := v.provider(ctx, cfg.JWKS).Keys(ctx, tokenKid(token))
+		if err != nil {
+			// Static keys may still verify the token while the key set is unavailable
+			if len(keys) == 0 {
+				return nil, err
+			}
+			v.logger.Warn("JWKS keys unavailable, using static keys only", "error", err)
+		}
+		keys = append(append([]parsedKey{}, keys...), jwksKeys...)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(cfg.Algorithms()),
+		jwt.WithLeeway(cfg.ClockSkew),
+		// Tokens without exp would be valid forever with nothing to revoke them
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if len(cfg.Audiences) > 0 {
+		options = append(options, jwt.WithAudience(cfg.Audiences...))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.NewParser(options...).ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return selectKeys(t, keys)
+	})
+	if err != nil {
+		v.logger.Debug("JWT verification failed", "error", err)
+		return nil, mapJWTError(err)
+	}
+
+	subject, _ := claims.GetSubject()
+
+	return &entities.Principal{
+		Subject:  subject,
+		AuthType: entities.AuthTypeJWT,
+		Scopes:   claimValues(claims, "scope", "scp"),
+		Roles:    claimValues(claims, "roles", "role"),
+		Claims:   claims,
+	}, nil
+}
+
+// Close stops the background refresh of every JWKS provider
+func (v *JWTValidator) Close() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, provider := range v.providers {
+		provider.Close()
+	}
+	return nil
+}
+
+// provider returns the shared provider of a key set source, starting it on first use
+func (v *JWTValidator) provider(ctx context.Context, cfg *entities.JWKSConfig) *JWKSProvider {
+	source := cfg.Source()
+
+	v.mu.RLock()
+	provider, ok := v.providers[source]
+	v.mu.RUnlock()
+	if ok {
+		return provider
+	}
+
+	v.mu.Lock()
+	if provider, ok = v.providers[source]; ok {
+		v.mu.Unlock()
+		return provider
+	}
+	provider = NewJWKSProvider(cfg, v.logger)
+	v.providers[source] = provider
+	v.mu.Unlock()
+
+	provider.Start(ctx)
+	return provider
+}
+
+func (v *JWTValidator) parsedKeys(cfg *entities.JWTConfig) ([]parsedKey, error) {
+	v.mu.RLock()
+	keys, ok := v.keys[cfg]
+	v.mu.RUnlock()
+	if ok {
+		return keys, nil
+	}
+
+	keys = make([]parsedKey, 0, len(cfg.Keys))
+	for _, key := range cfg.Keys {
+		parsed, err := parseKey(key)
+		if err != nil {
+			v.logger.Error("Failed to parse JWT key", "kid", key.ID, "algorithm", key.Algorithm, "error", err)
+			return nil, err
+		}
+		keys = append(keys, parsed)
+	}
+
+	v.mu.Lock()
+	v.keys[cfg] = keys
+	v.mu.Unlock()
+
+	return keys, nil
+}
+
+func parseKey(key entities.JWTKey) (parsedKey, error) {
+	parsed := parsedKey{id: key.ID, algorithm: key.Algorithm}
+
+	var err error
+	switch key.Algorithm {
+	case entities.JWTAlgorithmHS256:
+		parsed.key = []byte(key.Secret)
+	case entities.JWTAlgorithmRS256:
+		parsed.key, err = jwt.ParseRSAPublicKeyFromPEM([]byte(key.PublicKey))
+	case entities.JWTAlgorithmES256:
+		parsed.key, err = jwt.ParseECPublicKeyFromPEM([]byte(key.PublicKey))
+	default:
+		return parsed, domainErrors.ErrJWTUnsupportedAlgorithm
+	}
+	if err != nil {
+		return parsed, fmt.Errorf("invalid %s public key %q: %w", key.Algorithm, key.ID, err)
+	}
+	return parsed, nil
+}
+
+// selectKeys narrows the candidate keys down to the token's algorithm and,
+// when the token carries one, its kid
+func selectKeys(token *jwt.Token, keys []parsedKey) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	var set jwt.VerificationKeySet
+	for _, key := range keys {
+		if key.algorithm != token.Method.Alg() {
+			continue
+		}
+		if kid != "" && key.id != "" && key.id != kid {
+			continue
+		}
+		set.Keys = append(set.Keys, key.key)
+	}
+
+	if len(set.Keys) == 0 {
+		return nil, errors.New("no verification key matches token")
+	}
+	return set, nil
+}
+
+// claimValues collects the values of the first present claim, accepting
+// either a space-delimited string or a JSON array
+func claimValues(claims jwt.MapClaims, names ...string) []string {
+	for _, name := range names {
+		switch value := claims[name].(type) {
+		case string:
+			return strings.Fields(value)
+		case []interface{}:
+			values := make([]string, 0, len(value))
+			for _, item := range value {
+				if str, ok := item.(string); ok {
+					values = append(values, str)
+				}
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+// tokenKid reads the kid header without verifying the token
+func tokenKid(token string) string {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return ""
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func mapJWTError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return domainErrors.ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return domainErrors.ErrTokenNotYetValid
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return domainErrors.ErrTokenInvalidIssuer
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return domainErrors.ErrTokenInvalidAudience
+	default:
+		return domainErrors.ErrInvalidToken
+	}
+}
@@ -0,0 +1,163 @@
+package auth_test
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func publicKeyPEM(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestJWTValidator_Validate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	cfg := &entities.JWTConfig{
+		Issuer:    "https://issuer.internal",
+		Audiences: []string{"api-gateway"},
+		Keys: []entities.JWTKey{
+			{ID: "hs", Algorithm: entities.JWTAlgorithmHS256, Secret: "top-secret"},
+			{ID: "rs", Algorithm: entities.JWTAlgorithmRS256, PublicKey: publicKeyPEM(t, &rsaKey.PublicKey)},
+			{ID: "es", Algorithm: entities.JWTAlgorithmES256, PublicKey: publicKeyPEM(t, &ecKey.PublicKey)},
+		},
+	}
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub": "user-42",
+			"iss": "https://issuer.internal",
+			"aud": "api-gateway",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr error
+	}{
+		{
+			name: "valid HS256 token",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodHS256, "hs", []byte("top-secret"), validClaims())
+			},
+		},
+		{
+			name: "valid RS256 token",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodRS256, "rs", rsaKey, validClaims())
+			},
+		},
+		{
+			name: "valid ES256 token without kid",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodES256, "", ecKey, validClaims())
+			},
+		},
+		{
+			name: "wrong HS256 secret",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodHS256, "hs", []byte("other"), validClaims())
+			},
+			wantErr: domainErrors.ErrInvalidToken,
+		},
+		{
+			name: "expired token",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return signToken(t, jwt.SigningMethodHS256, "hs", []byte("top-secret"), claims)
+			},
+			wantErr: domainErrors.ErrTokenExpired,
+		},
+		{
+			name: "token without expiry",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return signToken(t, jwt.SigningMethodHS256, "hs", []byte("top-secret"), claims)
+			},
+			wantErr: domainErrors.ErrInvalidToken,
+		},
+		{
+			name: "token not valid yet",
+			token: func() string {
+				claims := validClaims()
+				claims["nbf"] = time.Now().Add(time.Hour).Unix()
+				return signToken(t, jwt.SigningMethodHS256, "hs", []byte("top-secret"), claims)
+			},
+			wantErr: domainErrors.ErrTokenNotYetValid,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "https://evil.example"
+				return signToken(t, jwt.SigningMethodHS256, "hs", []byte("top-secret"), claims)
+			},
+			wantErr: domainErrors.ErrTokenInvalidIssuer,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "someone-else"
+				return signToken(t, jwt.SigningMethodHS256, "hs", []byte("top-secret"), claims)
+			},
+			wantErr: domainErrors.ErrTokenInvalidAudience,
+		},
+		{
+			name: "unknown kid",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodRS256, "missing", rsaKey, validClaims())
+			},
+			wantErr: domainErrors.ErrInvalidToken,
+		},
+	}
+
+	validator := auth.NewJWTValidator(logger.New("test"))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := validator.Validate(context.Background(), tt.token(), cfg)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, principal)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-42", principal.Subject)
+			assert.Equal(t, entities.AuthTypeJWT, principal.AuthType)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+)
+
+var errClientCertificateUnverified = errors.New("certificate was not verified by the listener and the route has no ca bundle")
+
+// MTLSValidator authenticates requests by the client certificate presented
+// during the TLS handshake
+type MTLSValidator struct {
+	logger logger.Logger
+}
+
+func NewMTLSValidator(log logger.Logger) *MTLSValidator {
+	return &MTLSValidator{
+		logger: log.With("component", "mtls_validator"),
+	}
+}
+
+func (v *MTLSValidator) Validate(ctx context.Context, req *entities.HTTPRequest, cfg *entities.MTLSConfig) (*entities.Principal, error) {
+	if len(req.ClientCertificates) == 0 {
+		return nil, domainErrors.ErrMissingClientCertificate
+	}
+	if cfg == nil {
+		cfg = &entities.MTLSConfig{}
+	}
+	leaf := req.ClientCertificates[0]
+
+	if err := verifyClientCertificate(req, cfg); err != nil {
+		v.logger.Warn("Client certificate not trusted", "subject", leaf.Subject.String(), "issuer", leaf.Issuer.String(), "error", err)
+		return nil, domainErrors.ErrInvalidClientCertificate
+	}
+
+	if !cfg.Allows(leaf) {
+		v.logger.Warn("Client certificate identity not allowed", "subject", leaf.Subject.String())
+		return nil, domainErrors.ErrClientCertificateNotAllowed
+	}
+
+	fingerprint := sha256.Sum256(leaf.Raw)
+	return &entities.Principal{
+		Subject:  entities.CertificateIdentity(leaf),
+		AuthType: entities.AuthTypeMTLS,
+		Claims: map[string]interface{}{
+			"subject":     leaf.Subject.String(),
+			"issuer":      leaf.Issuer.String(),
+			"serial":      leaf.SerialNumber.String(),
+			"fingerprint": hex.EncodeToString(fingerprint[:]),
+		},
+	}, nil
+}
+
+// verifyClientCertificate checks the chain against the route's CA bundle, or
+// relies on the listener having verified it when the route has none
+func verifyClientCertificate(req *entities.HTTPRequest, cfg *entities.MTLSConfig) error {
+	if cfg.ClientCAs == nil {
+		if !req.ClientCertificateVerified {
+			return errClientCertificateUnverified
+		}
+		return nil
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range req.ClientCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := req.ClientCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         cfg.ClientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err
+}
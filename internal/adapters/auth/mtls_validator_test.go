@@ -0,0 +1,141 @@
+package auth_test
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func (ca *testCA) issueClient(t *testing.T, commonName string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestMTLSValidator_Validate(t *testing.T) {
+	partnerCA := newTestCA(t, "partner-ca")
+	otherCA := newTestCA(t, "other-ca")
+	acme := partnerCA.issueClient(t, "acme-billing")
+	impostor := otherCA.issueClient(t, "acme-billing")
+
+	tests := []struct {
+		name     string
+		request  *entities.HTTPRequest
+		config   *entities.MTLSConfig
+		wantErr  error
+		wantUser string
+	}{
+		{
+			name:    "no certificate",
+			request: &entities.HTTPRequest{},
+			config:  &entities.MTLSConfig{ClientCAs: partnerCA.pool()},
+			wantErr: domainErrors.ErrMissingClientCertificate,
+		},
+		{
+			name:     "verified by route ca bundle",
+			request:  &entities.HTTPRequest{ClientCertificates: []*x509.Certificate{acme}},
+			config:   &entities.MTLSConfig{ClientCAs: partnerCA.pool(), Subjects: []string{"acme-billing"}},
+			wantUser: "acme-billing",
+		},
+		{
+			name:    "signed by another ca",
+			request: &entities.HTTPRequest{ClientCertificates: []*x509.Certificate{impostor}},
+			config:  &entities.MTLSConfig{ClientCAs: partnerCA.pool()},
+			wantErr: domainErrors.ErrInvalidClientCertificate,
+		},
+		{
+			name:     "verified by listener",
+			request:  &entities.HTTPRequest{ClientCertificates: []*x509.Certificate{acme}, ClientCertificateVerified: true},
+			config:   nil,
+			wantUser: "acme-billing",
+		},
+		{
+			name:    "not verified by listener and no route ca bundle",
+			request: &entities.HTTPRequest{ClientCertificates: []*x509.Certificate{impostor}},
+			config:  &entities.MTLSConfig{},
+			wantErr: domainErrors.ErrInvalidClientCertificate,
+		},
+		{
+			name:    "identity not allowed",
+			request: &entities.HTTPRequest{ClientCertificates: []*x509.Certificate{acme}},
+			config:  &entities.MTLSConfig{ClientCAs: partnerCA.pool(), Subjects: []string{"globex"}},
+			wantErr: domainErrors.ErrClientCertificateNotAllowed,
+		},
+	}
+
+	validator := auth.NewMTLSValidator(logger.New("test"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := validator.Validate(context.Background(), tt.request, tt.config)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, principal)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUser, principal.Subject)
+			assert.Equal(t, entities.AuthTypeMTLS, principal.AuthType)
+			assert.Equal(t, "CN=partner-ca", principal.Claims["issuer"])
+		})
+	}
+}
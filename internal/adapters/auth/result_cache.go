@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"api-gateway/internal/domain/entities"
+	"container/list"
+	"sync"
+	"time"
+)
+
+// defaultResultCacheSize bounds the decisions a validator remembers, so
+// requests carrying made up credentials cannot grow the cache without limit
+const defaultResultCacheSize = 10000
+
+// resultCacheEntry is the outcome of validating one set of credentials
+type resultCacheEntry struct {
+	key       string
+	principal *entities.Principal
+	err       error
+	expiresAt time.Time
+}
+
+// resultCache is a bounded LRU of validation outcomes. Expired entries are
+// dropped when looked up and the least recently used entry is evicted once
+// the cache is full.
+type resultCache struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func newResultCache(size int) *resultCache {
+	if size <= 0 {
+		size = defaultResultCacheSize
+	}
+	return &resultCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get returns the cached outcome of a key that has not expired yet
+func (c *resultCache) get(key string) (*resultCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*resultCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+// put caches the outcome of a key until expiresAt
+func (c *resultCache) put(key string, principal *entities.Principal, err error, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&resultCacheEntry{key: key, principal: principal, err: err, expiresAt: expiresAt})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*resultCacheEntry).key)
+	}
+}
@@ -3,33 +3,106 @@ package auth
 import (
 	"api-gateway/internal/application/ports"
 	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
 	"api-gateway/pkg/logger"
 	"context"
 	"errors"
+	"strings"
+	"time"
 )
 
 type ValidatorRepository struct {
-	apiKeyRepo ports.ApiKeyRepository
-	logger     logger.Logger
+	apiKeyRepo             ports.ApiKeyRepository
+	jwtValidator           *JWTValidator
+	introspectionValidator *IntrospectionValidator
+	hmacValidator          *HMACValidator
+	mtlsValidator          *MTLSValidator
+	basicValidator         *BasicAuthValidator
+	externalAuthorizer     *ExternalAuthorizer
+	logger                 logger.Logger
 }
 
-func NewAuthValidator(log logger.Logger, apiKeyRepo ports.ApiKeyRepository) ports.AuthValidator {
+// NewAuthValidator creates the validator of every policy type. identityTokenHeader
+// is the header the signed identity token is sent to backends in, it is never
+// shown to external authorization services.
+func NewAuthValidator(log logger.Logger, apiKeyRepo ports.ApiKeyRepository, nonces ports.NonceStore, identityTokenHeader string) ports.AuthValidator {
 	return &ValidatorRepository{
-		logger:     log,
-		apiKeyRepo: apiKeyRepo,
+		logger:                 log,
+		apiKeyRepo:             apiKeyRepo,
+		jwtValidator:           NewJWTValidator(log),
+		introspectionValidator: NewIntrospectionValidator(log),
+		hmacValidator:          NewHMACValidator(log, nonces),
+		mtlsValidator:          NewMTLSValidator(log),
+		basicValidator:         NewBasicAuthValidator(log, apiKeyRepo),
+		externalAuthorizer:     NewExternalAuthorizer(log, identityTokenHeader),
 	}
 }
 
-func (v ValidatorRepository) Validate(ctx context.Context, token string, policy *entities.AuthPolicy) error {
-	isValidToken, err := v.apiKeyRepo.IsValidKey(ctx, token)
+func (v ValidatorRepository) Validate(ctx context.Context, token string, policy *entities.AuthPolicy) (*entities.Principal, error) {
+	switch policy.Type {
+	case entities.AuthTypeAPIKey:
+		record, err := findUsableApiKey(ctx, v.apiKeyRepo, v.logger, token)
+		if err != nil {
+			return nil, err
+		}
+		return apiKeyPrincipal(record), nil
+	case entities.AuthTypeBasic:
+		return v.basicValidator.Validate(ctx, token, policy.Basic)
+	case entities.AuthTypeJWT:
+		return v.jwtValidator.Validate(ctx, token, policy.JWT)
+	case entities.AuthTypeOAuth2Introspection:
+		return v.introspectionValidator.Validate(ctx, token, policy.Introspection)
+	}
+	return nil, domainErrors.ErrUnsupportedAuthType
+}
+
+func (v ValidatorRepository) ValidateRequest(ctx context.Context, req *entities.HTTPRequest, policy *entities.AuthPolicy) (*entities.Principal, error) {
+	switch policy.Type {
+	case entities.AuthTypeHMAC:
+		return v.hmacValidator.Validate(ctx, req, policy.HMAC)
+	case entities.AuthTypeMTLS:
+		return v.mtlsValidator.Validate(ctx, req, policy.MTLS)
+	case entities.AuthTypeExternal:
+		return v.externalAuthorizer.Validate(ctx, req, policy.External)
+	}
+	return nil, domainErrors.ErrUnsupportedAuthType
+}
+
+// findUsableApiKey looks up a presented key, checks that it can still be used and records its use
+func findUsableApiKey(ctx context.Context, repo ports.ApiKeyRepository, log logger.Logger, token string) (*entities.ApiKey, error) {
+	record, err := repo.FindKey(ctx, token)
+	if errors.Is(err, domainErrors.ErrApiKeyNotFound) {
+		keyID, _ := entities.ParseApiKey(token)
+		log.Warn("invalid api key", "key_id", keyID)
+		return nil, domainErrors.ErrInvalidApiKey
+	}
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := record.CheckUsable(time.Now()); err != nil {
+		log.Warn("api key not usable", "key_id", record.ID, "owner", record.Owner, "reason", err)
+		return nil, err
 	}
-	if !isValidToken {
-		v.logger.Error("invalid api key", "token", token)
-		return errors.New("invalid api key")
+	if recorder, ok := repo.(ports.ApiKeyUsageRecorder); ok {
+		recorder.RecordKeyUsage(ctx, record.ID)
+	}
+	return record, nil
+}
+
+// apiKeyPrincipal attributes a request to the owner of the key
+func apiKeyPrincipal(record *entities.ApiKey) *entities.Principal {
+	return &entities.Principal{
+		Subject:  record.Owner,
+		AuthType: entities.AuthTypeAPIKey,
+		KeyID:    record.ID,
+		Scopes:   record.Scopes,
+		Roles:    record.Roles,
 	}
-	return nil
+}
+
+// Close releases background resources such as JWKS refresh loops
+func (v ValidatorRepository) Close() error {
+	return v.jwtValidator.Close()
 }
 
 func (v ValidatorRepository) ExtractToken(ctx context.Context, headers map[string][]string, authType string) (string, error) {
@@ -37,17 +110,31 @@ func (v ValidatorRepository) ExtractToken(ctx context.Context, headers map[strin
 	if authType == entities.AuthTypeAPIKey {
 		apiTokenHeader := headers["X-Api-Key"]
 		if len(apiTokenHeader) == 0 {
-			return "", errors.New("missing api key")
+			return "", domainErrors.ErrMissingApiKey
 		}
+		// The key itself is checked once in Validate
 		apiToken = apiTokenHeader[0]
-		isValid, err := v.apiKeyRepo.IsValidKey(ctx, apiToken)
-		if err != nil {
-			return "", err
-		}
-		if !isValid {
-			return "", errors.New("invalid api key")
-		}
 		return apiToken, nil
 	}
+	if authType == entities.AuthTypeJWT || authType == entities.AuthTypeOAuth2Introspection {
+		return extractBearerToken(headers)
+	}
+	if authType == entities.AuthTypeBasic {
+		return extractBasicCredentials(headers)
+	}
 	return "apiToken", errors.New("invalid auth type")
 }
+
+// extractBearerToken reads the token from an "Authorization: Bearer <token>" header
+func extractBearerToken(headers map[string][]string) (string, error) {
+	authorization := headers["Authorization"]
+	if len(authorization) == 0 {
+		return "", domainErrors.ErrMissingBearerToken
+	}
+
+	scheme, token, found := strings.Cut(authorization[0], " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", domainErrors.ErrMissingBearerToken
+	}
+	return strings.TrimSpace(token), nil
+}
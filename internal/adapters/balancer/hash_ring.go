@@ -0,0 +1,50 @@
+package balancer
+
+import (
+	"hash/crc32"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// hashRingReplicas is the number of points per unit of weight a target has on
+// the ring, more points spread keys more evenly
+const hashRingReplicas = 160
+
+type ringPoint struct {
+	hash   uint32
+	member *member
+}
+
+// hashRing maps keys to members such that adding or removing a target only
+// moves the keys of that target
+type hashRing struct {
+	points []ringPoint
+}
+
+func newHashRing(members []*member) *hashRing {
+	ring := &hashRing{}
+	for _, m := range members {
+		for i := 0; i < m.target.Weight*hashRingReplicas; i++ {
+			ring.points = append(ring.points, ringPoint{
+				hash:   crc32.ChecksumIEEE([]byte(m.target.Host + "#" + strconv.Itoa(i))),
+				member: m,
+			})
+		}
+	}
+	sort.Slice(ring.points, func(i, j int) bool { return ring.points[i].hash < ring.points[j].hash })
+	return ring
+}
+
+// lookup returns the healthy member owning the first point at or after the
+// key's hash, so only the keys of an unhealthy member move while it is out
+func (r *hashRing) lookup(key string, healthy []*member) *member {
+	hash := crc32.ChecksumIEEE([]byte(key))
+	start := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= hash })
+	for i := 0; i < len(r.points); i++ {
+		if candidate := r.points[(start+i)%len(r.points)].member; slices.Contains(healthy, candidate) {
+			return candidate
+		}
+	}
+	return healthy[0]
+}
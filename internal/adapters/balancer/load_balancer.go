@@ -0,0 +1,171 @@
+package balancer
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+// member is a target of a backend with the state strategies keep about it
+type member struct {
+	target   entities.Target
+	inFlight int
+	// currentWeight is the smooth weighted round robin counter
+	currentWeight int
+}
+
+// pool holds the members of one backend
+type pool struct {
+	targets  []entities.Target
+	strategy entities.LoadBalancing
+	members  []*member
+	// next is the round robin position
+	next int
+	ring *hashRing
+}
+
+// LoadBalancer spreads requests over the healthy targets of backends. Round
+// robin positions and connection counts are kept per backend in process
+// memory, so every gateway instance balances the requests it serves on its own.
+type LoadBalancer struct {
+	mu     sync.Mutex
+	pools  map[string]*pool
+	health ports.HealthChecker
+}
+
+// NewLoadBalancer skips the targets the health checker reports unhealthy, a nil
+// checker keeps every target in rotation
+func NewLoadBalancer(health ports.HealthChecker) ports.LoadBalancer {
+	return &LoadBalancer{pools: make(map[string]*pool), health: health}
+}
+
+func (b *LoadBalancer) Pick(backend *entities.Backend, hashKey string) (*entities.Target, ports.TargetRelease, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pool := b.pool(backend)
+	healthy := make([]*member, 0, len(pool.members))
+	for _, candidate := range pool.members {
+		if b.health == nil || b.health.IsHealthy(backend.Id, candidate.target.Host) {
+			healthy = append(healthy, candidate)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil, nil, domainErrors.ErrBackendUnavailable
+	}
+
+	picked := pool.pick(healthy, hashKey)
+	picked.inFlight++
+
+	target := picked.target
+	var once sync.Once
+	release := func(outcome entities.TargetOutcome) {
+		once.Do(func() {
+			b.mu.Lock()
+			picked.inFlight--
+			b.mu.Unlock()
+
+			if b.health != nil {
+				b.health.Observe(backend.Id, target.Host, outcome)
+			}
+		})
+	}
+	return &target, release, nil
+}
+
+// pool returns the pool of a backend, starting over when its targets changed
+func (b *LoadBalancer) pool(backend *entities.Backend) *pool {
+	existing, ok := b.pools[backend.Id]
+	if ok && existing.strategy == backend.LoadBalancing && slices.Equal(existing.targets, backend.Targets) {
+		return existing
+	}
+
+	created := &pool{
+		targets:  slices.Clone(backend.Targets),
+		strategy: backend.LoadBalancing,
+	}
+	for _, target := range backend.Targets {
+		created.members = append(created.members, &member{target: target})
+	}
+	if backend.LoadBalancing.Strategy == entities.BalancerConsistentHash {
+		created.ring = newHashRing(created.members)
+	}
+	b.pools[backend.Id] = created
+	return created
+}
+
+// pick chooses among the healthy members, which are never empty
+func (p *pool) pick(healthy []*member, hashKey string) *member {
+	switch p.strategy.Strategy {
+	case entities.BalancerWeightedRoundRobin:
+		return p.weightedRoundRobin(healthy)
+	case entities.BalancerLeastConnections:
+		return p.leastConnections(healthy)
+	case entities.BalancerRandomTwoChoices:
+		return p.randomTwoChoices(healthy)
+	case entities.BalancerConsistentHash:
+		if hashKey != "" {
+			return p.ring.lookup(hashKey, healthy)
+		}
+	}
+	return p.roundRobin(healthy)
+}
+
+func (p *pool) roundRobin(healthy []*member) *member {
+	picked := healthy[p.next%len(healthy)]
+	p.next++
+	return picked
+}
+
+// weightedRoundRobin is the smooth weighted round robin of nginx, which
+// interleaves targets rather than sending a heavy target requests in a row
+func (p *pool) weightedRoundRobin(healthy []*member) *member {
+	var picked *member
+	total := 0
+	for _, candidate := range healthy {
+		candidate.currentWeight += candidate.target.Weight
+		total += candidate.target.Weight
+		if picked == nil || candidate.currentWeight > picked.currentWeight {
+			picked = candidate
+		}
+	}
+	picked.currentWeight -= total
+	return picked
+}
+
+// leastConnections starts looking at the round robin position, so that ties
+// do not always go to the first target
+func (p *pool) leastConnections(healthy []*member) *member {
+	var picked *member
+	for i := range healthy {
+		candidate := healthy[(p.next+i)%len(healthy)]
+		if picked == nil || lessLoaded(candidate, picked) {
+			picked = candidate
+		}
+	}
+	p.next++
+	return picked
+}
+
+func (p *pool) randomTwoChoices(healthy []*member) *member {
+	if len(healthy) == 1 {
+		return healthy[0]
+	}
+	first := rand.IntN(len(healthy))
+	second := rand.IntN(len(healthy) - 1)
+	if second >= first {
+		second++
+	}
+	if lessLoaded(healthy[second], healthy[first]) {
+		return healthy[second]
+	}
+	return healthy[first]
+}
+
+// lessLoaded compares requests in flight per weight
+func lessLoaded(a, b *member) bool {
+	return a.inFlight*b.target.Weight < b.inFlight*a.target.Weight
+}
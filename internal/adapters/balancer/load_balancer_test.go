@@ -0,0 +1,249 @@
+package balancer_test
+
+import (
+	"api-gateway/internal/adapters/balancer"
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBackend(strategy string, weights ...int) *entities.Backend {
+	backend := &entities.Backend{
+		Id:            "orders",
+		LoadBalancing: entities.LoadBalancing{Strategy: strategy},
+	}
+	for i, weight := range weights {
+		backend.Targets = append(backend.Targets, entities.Target{Host: "http://orders-" + strconv.Itoa(i) + ":8080", Weight: weight})
+	}
+	backend.Host = backend.Targets[0].Host
+	return backend
+}
+
+// pickCounts picks n times, handing every target back right away
+func pickCounts(t *testing.T, lb ports.LoadBalancer, backend *entities.Backend, n int) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		target, release, err := lb.Pick(backend, "")
+		require.NoError(t, err)
+		counts[target.Host]++
+		release(entities.TargetOutcomeSuccess)
+	}
+	return counts
+}
+
+func TestLoadBalancer_RoundRobin(t *testing.T) {
+	lb := balancer.NewLoadBalancer(nil)
+	backend := newBackend(entities.BalancerRoundRobin, 5, 1, 1)
+
+	var picked []string
+	for i := 0; i < 6; i++ {
+		target, release, err := lb.Pick(backend, "")
+		require.NoError(t, err)
+		picked = append(picked, target.Host)
+		release(entities.TargetOutcomeSuccess)
+	}
+
+	assert.Equal(t, []string{
+		"http://orders-0:8080", "http://orders-1:8080", "http://orders-2:8080",
+		"http://orders-0:8080", "http://orders-1:8080", "http://orders-2:8080",
+	}, picked, "round robin ignores weights")
+}
+
+func TestLoadBalancer_WeightedRoundRobin(t *testing.T) {
+	lb := balancer.NewLoadBalancer(nil)
+	backend := newBackend(entities.BalancerWeightedRoundRobin, 3, 1)
+
+	var picked []string
+	for i := 0; i < 4; i++ {
+		target, release, err := lb.Pick(backend, "")
+		require.NoError(t, err)
+		picked = append(picked, target.Host)
+		release(entities.TargetOutcomeSuccess)
+	}
+	assert.Equal(t, []string{"http://orders-0:8080", "http://orders-0:8080", "http://orders-1:8080", "http://orders-0:8080"}, picked,
+		"the light target is interleaved rather than last")
+
+	counts := pickCounts(t, lb, backend, 400)
+	assert.Equal(t, 300, counts["http://orders-0:8080"])
+	assert.Equal(t, 100, counts["http://orders-1:8080"])
+}
+
+func TestLoadBalancer_LeastConnections(t *testing.T) {
+	lb := balancer.NewLoadBalancer(nil)
+	backend := newBackend(entities.BalancerLeastConnections, 1, 1, 2)
+
+	// The heavy target takes two requests for every one of the others
+	held := make(map[string]int)
+	var releases []ports.TargetRelease
+	for i := 0; i < 8; i++ {
+		target, release, err := lb.Pick(backend, "")
+		require.NoError(t, err)
+		held[target.Host]++
+		releases = append(releases, release)
+	}
+	assert.Equal(t, map[string]int{"http://orders-0:8080": 2, "http://orders-1:8080": 2, "http://orders-2:8080": 4}, held)
+
+	// A freed connection attracts the next request, handing back twice counts once
+	releases[0](entities.TargetOutcomeSuccess)
+	releases[0](entities.TargetOutcomeSuccess)
+	target, _, err := lb.Pick(backend, "")
+	require.NoError(t, err)
+	assert.Equal(t, "http://orders-0:8080", target.Host)
+
+	busy := newBackend(entities.BalancerLeastConnections, 1, 1)
+	busy.Id = "users"
+	first, _, err := lb.Pick(busy, "")
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		target, release, err := lb.Pick(busy, "")
+		require.NoError(t, err)
+		assert.NotEqual(t, first.Host, target.Host, "the target still serving a request is avoided")
+		release(entities.TargetOutcomeSuccess)
+	}
+}
+
+func TestLoadBalancer_RandomTwoChoices(t *testing.T) {
+	lb := balancer.NewLoadBalancer(nil)
+	backend := newBackend(entities.BalancerRandomTwoChoices, 1, 1)
+
+	first, _, err := lb.Pick(backend, "")
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		target, release, err := lb.Pick(backend, "")
+		require.NoError(t, err)
+		assert.NotEqual(t, first.Host, target.Host, "with two targets the idle one always wins")
+		release(entities.TargetOutcomeSuccess)
+	}
+
+	counts := pickCounts(t, lb, newBackend(entities.BalancerRandomTwoChoices, 1, 1, 1), 300)
+	assert.Len(t, counts, 3)
+}
+
+func TestLoadBalancer_ConsistentHash(t *testing.T) {
+	lb := balancer.NewLoadBalancer(nil)
+	backend := newBackend(entities.BalancerConsistentHash, 1, 1, 1)
+	backend.LoadBalancing.HashHeader = "X-User-ID"
+
+	owners := make(map[string]string)
+	for i := 0; i < 300; i++ {
+		key := "user-" + strconv.Itoa(i)
+		target, release, err := lb.Pick(backend, key)
+		require.NoError(t, err)
+		owners[key] = target.Host
+		release(entities.TargetOutcomeSuccess)
+	}
+	counts := make(map[string]int)
+	for key, owner := range owners {
+		counts[owner]++
+		target, _, err := lb.Pick(backend, key)
+		require.NoError(t, err)
+		assert.Equal(t, owner, target.Host, "the same key goes to the same target")
+	}
+	for host, count := range counts {
+		assert.Greater(t, count, 50, "keys are spread over %s", host)
+	}
+
+	// Removing a target only moves the keys it owned
+	shrunk := newBackend(entities.BalancerConsistentHash, 1, 1)
+	shrunk.LoadBalancing.HashHeader = "X-User-ID"
+	for key, owner := range owners {
+		if owner == "http://orders-2:8080" {
+			continue
+		}
+		target, _, err := lb.Pick(shrunk, key)
+		require.NoError(t, err)
+		assert.Equal(t, owner, target.Host)
+	}
+
+	// Requests without a key are balanced round robin
+	assert.Len(t, pickCounts(t, lb, backend, 3), 3)
+}
+
+// observedTargets records the outcomes reported to the health checker
+type observedTargets struct {
+	outcomes map[string][]entities.TargetOutcome
+}
+
+func (o *observedTargets) IsHealthy(backendID, host string) bool { return true }
+
+func (o *observedTargets) Observe(backendID, host string, outcome entities.TargetOutcome) {
+	o.outcomes[backendID+" "+host] = append(o.outcomes[backendID+" "+host], outcome)
+}
+
+func (o *observedTargets) Status() []entities.BackendHealth { return nil }
+
+func TestLoadBalancer_ReportsOutcomes(t *testing.T) {
+	observed := &observedTargets{outcomes: make(map[string][]entities.TargetOutcome)}
+	lb := balancer.NewLoadBalancer(observed)
+	backend := newBackend(entities.BalancerRoundRobin, 1)
+
+	_, release, err := lb.Pick(backend, "")
+	require.NoError(t, err)
+	release(entities.TargetOutcomeFailure)
+	release(entities.TargetOutcomeSuccess)
+
+	assert.Equal(t, map[string][]entities.TargetOutcome{
+		"orders http://orders-0:8080": {entities.TargetOutcomeFailure},
+	}, observed.outcomes, "only the first release counts")
+}
+
+func TestLoadBalancer_NoTargets(t *testing.T) {
+	lb := balancer.NewLoadBalancer(nil)
+
+	_, _, err := lb.Pick(&entities.Backend{Id: "orders", LoadBalancing: entities.LoadBalancing{Strategy: entities.BalancerRoundRobin}}, "")
+
+	assert.ErrorIs(t, err, domainErrors.ErrBackendUnavailable)
+}
+
+// downTargets reports the listed hosts unhealthy
+type downTargets map[string]bool
+
+func (d downTargets) IsHealthy(backendID, host string) bool { return !d[host] }
+
+func (d downTargets) Observe(backendID, host string, outcome entities.TargetOutcome) {}
+
+func (d downTargets) Status() []entities.BackendHealth { return nil }
+
+func TestLoadBalancer_SkipsUnhealthyTargets(t *testing.T) {
+	down := downTargets{"http://orders-1:8080": true}
+	lb := balancer.NewLoadBalancer(down)
+
+	strategies := []string{
+		entities.BalancerRoundRobin,
+		entities.BalancerWeightedRoundRobin,
+		entities.BalancerLeastConnections,
+		entities.BalancerRandomTwoChoices,
+	}
+	for _, strategy := range strategies {
+		counts := pickCounts(t, lb, newBackend(strategy, 1, 1, 1), 30)
+		assert.Zero(t, counts["http://orders-1:8080"], strategy)
+		assert.Len(t, counts, 2, strategy)
+	}
+
+	// Keys of healthy targets stay put, those of the unhealthy one move
+	hashed := newBackend(entities.BalancerConsistentHash, 1, 1, 1)
+	hashed.LoadBalancing.HashHeader = "X-User-ID"
+	healthy := balancer.NewLoadBalancer(nil)
+	for i := 0; i < 100; i++ {
+		key := "user-" + strconv.Itoa(i)
+		owner, _, err := healthy.Pick(hashed, key)
+		require.NoError(t, err)
+		target, _, err := lb.Pick(hashed, key)
+		require.NoError(t, err)
+		assert.NotEqual(t, "http://orders-1:8080", target.Host)
+		if owner.Host != "http://orders-1:8080" {
+			assert.Equal(t, owner.Host, target.Host)
+		}
+	}
+
+	down["http://orders-0:8080"] = true
+	down["http://orders-2:8080"] = true
+	_, _, err := lb.Pick(newBackend(entities.BalancerRoundRobin, 1, 1, 1), "")
+	assert.ErrorIs(t, err, domainErrors.ErrBackendUnavailable)
+}
@@ -0,0 +1,317 @@
+package healthcheck
+
+import (
+	"api-gateway/internal/domain/entities"
+	"api-gateway/pkg/logger"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// targetState tracks the probes and the live traffic of one target
+type targetState struct {
+	host string
+	// probeHealthy is the verdict of the health checks, true without them
+	probeHealthy         bool
+	lastCheck            time.Time
+	lastError            string
+	consecutiveSuccesses int
+	consecutiveFailures  int
+	// consecutiveErrors counts requests of live traffic the target failed in a row
+	consecutiveErrors int
+	// ejections is the number of ejections in a row, it lengthens the next one
+	ejections    int
+	ejectedUntil time.Time
+}
+
+// backendState holds the checked targets of one backend
+type backendState struct {
+	backend *entities.Backend
+	targets map[string]*targetState
+}
+
+// HealthChecker probes the targets of backends with a health check in the
+// background and ejects targets of backends with outlier detection that fail
+// live traffic. Targets start healthy, so traffic flows before the first
+// probes have run. Health is kept per gateway instance.
+type HealthChecker struct {
+	httpClient *http.Client
+	logger     logger.Logger
+
+	mu       sync.RWMutex
+	backends map[string]*backendState
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewHealthChecker checks the backends that have a health check or outlier
+// detection, the others are ignored
+func NewHealthChecker(backends []*entities.Backend, log logger.Logger) *HealthChecker {
+	checker := &HealthChecker{
+		httpClient: &http.Client{
+			// A redirect is an answer of the target itself
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		logger:   log.With("component", "health_checker"),
+		backends: make(map[string]*backendState),
+		stop:     make(chan struct{}),
+	}
+
+	for _, backend := range backends {
+		if backend.HealthCheck == nil && backend.OutlierDetection == nil {
+			continue
+		}
+		// The checker keeps its own copy, routes share the original across
+		// requests and never carry health, see ports.HealthChecker
+		tracked := *backend
+		tracked.UpdateHealth(true)
+		state := &backendState{backend: &tracked, targets: make(map[string]*targetState)}
+		for _, target := range backend.Targets {
+			state.targets[target.Host] = &targetState{host: target.Host, probeHealthy: true}
+		}
+		checker.backends[backend.Id] = state
+	}
+	return checker
+}
+
+// Start probes every target right away and then on the interval of its
+// backend until Close is called
+func (c *HealthChecker) Start(ctx context.Context) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, state := range c.backends {
+		if state.backend.HealthCheck == nil {
+			continue
+		}
+		for host := range state.targets {
+			c.wg.Add(1)
+			go c.run(ctx, state.backend, host)
+		}
+	}
+	c.logger.Info("Backend health checks started", "backends", len(c.backends))
+}
+
+func (c *HealthChecker) Close() error {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+	c.wg.Wait()
+	return nil
+}
+
+func (c *HealthChecker) IsHealthy(backendID, host string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	state, ok := c.backends[backendID]
+	if !ok {
+		return true
+	}
+	target, ok := state.targets[host]
+	return !ok || target.healthy(time.Now())
+}
+
+func (c *HealthChecker) Observe(backendID, host string, outcome entities.TargetOutcome) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, ok := c.backends[backendID]
+	if !ok || state.backend.OutlierDetection == nil || outcome == entities.TargetOutcomeIgnored {
+		return
+	}
+	target, ok := state.targets[host]
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	// Requests still in flight when the target was ejected tell nothing new
+	if now.Before(target.ejectedUntil) {
+		return
+	}
+	if outcome == entities.TargetOutcomeSuccess {
+		target.consecutiveErrors = 0
+		return
+	}
+
+	detection := state.backend.OutlierDetection
+	target.consecutiveErrors++
+	if target.consecutiveErrors < detection.ConsecutiveErrors {
+		return
+	}
+
+	// Targets failing their health checks are out of rotation as well
+	unavailable := 0
+	for _, other := range state.targets {
+		if !other.healthy(now) {
+			unavailable++
+		}
+	}
+	if unavailable >= detection.MaxEjected(len(state.targets)) {
+		c.logger.Warn("Backend target is an outlier, but too many targets are out of rotation already",
+			"backend_id", backendID,
+			"target", host,
+			"unavailable_targets", unavailable,
+		)
+		return
+	}
+
+	// A target that stayed in rotation long enough starts over with the base ejection time
+	if now.Sub(target.ejectedUntil) > detection.MaxEjectionTime {
+		target.ejections = 0
+	}
+	target.ejections++
+	target.consecutiveErrors = 0
+	target.ejectedUntil = now.Add(detection.EjectionTime(target.ejections))
+	c.logger.Warn("Backend target is an outlier, ejecting it",
+		"backend_id", backendID,
+		"target", host,
+		"consecutive_errors", detection.ConsecutiveErrors,
+		"ejections", target.ejections,
+		"ejected_until", target.ejectedUntil,
+	)
+	state.backend.UpdateHealth(state.healthy(now))
+}
+
+func (c *HealthChecker) Status() []entities.BackendHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]entities.BackendHealth, 0, len(c.backends))
+	for id, state := range c.backends {
+		// Ejections run out without an event, so the backend is brought up to date here
+		if healthy := state.healthy(now); healthy != state.backend.IsHealthy() {
+			state.backend.UpdateHealth(healthy)
+		}
+		status := entities.BackendHealth{
+			BackendID: id,
+			Healthy:   state.backend.IsHealthy(),
+			LastCheck: state.backend.LastHealthCheck,
+		}
+		for _, target := range state.backend.Targets {
+			status.Targets = append(status.Targets, state.targets[target.Host].status(now))
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].BackendID < statuses[j].BackendID })
+	return statuses
+}
+
+// run probes one target until the checker is closed or the context is done
+func (c *HealthChecker) run(ctx context.Context, backend *entities.Backend, host string) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(backend.HealthCheck.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.record(backend, host, c.probe(ctx, backend.HealthCheck, host))
+
+		select {
+		case <-ticker.C:
+		case <-c.stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// probe sends one health check request, nil means the target is healthy
+func (c *HealthChecker) probe(ctx context.Context, check *entities.HealthCheck, host string) error {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, host+check.Path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create health check request: %w", err)
+	}
+	req.Header.Set("User-Agent", "api-gateway-health-check")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("health check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if !check.IsExpectedStatus(resp.StatusCode) {
+		return fmt.Errorf("unexpected health check status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// record applies a probe result, flipping the target once a threshold is reached
+func (c *HealthChecker) record(backend *entities.Backend, host string, probeErr error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := c.backends[backend.Id]
+	target := state.targets[host]
+	now := time.Now()
+	target.lastCheck = now
+
+	if probeErr == nil {
+		target.lastError = ""
+		target.consecutiveFailures = 0
+		target.consecutiveSuccesses++
+		if !target.probeHealthy && target.consecutiveSuccesses >= backend.HealthCheck.HealthyThreshold {
+			target.probeHealthy = true
+			c.logger.Info("Backend target is healthy again",
+				"backend_id", backend.Id,
+				"target", host,
+			)
+		}
+	} else {
+		target.lastError = probeErr.Error()
+		target.consecutiveSuccesses = 0
+		target.consecutiveFailures++
+		if target.probeHealthy && target.consecutiveFailures >= backend.HealthCheck.UnhealthyThreshold {
+			target.probeHealthy = false
+			c.logger.Warn("Backend target is unhealthy, taking it out of rotation",
+				"backend_id", backend.Id,
+				"target", host,
+				"consecutive_failures", target.consecutiveFailures,
+				"error", probeErr,
+			)
+		}
+	}
+
+	state.backend.UpdateHealth(state.healthy(now))
+}
+
+// healthy reports whether any target of the backend is healthy
+func (s *backendState) healthy(now time.Time) bool {
+	for _, target := range s.targets {
+		if target.healthy(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// healthy reports whether the target passes its health checks and is not ejected
+func (t *targetState) healthy(now time.Time) bool {
+	return t.probeHealthy && !now.Before(t.ejectedUntil)
+}
+
+func (t *targetState) status(now time.Time) entities.TargetHealth {
+	health := entities.TargetHealth{
+		Host:      t.host,
+		Healthy:   t.healthy(now),
+		LastCheck: t.lastCheck,
+		LastError: t.lastError,
+	}
+	if now.Before(t.ejectedUntil) {
+		health.EjectedUntil = t.ejectedUntil
+	}
+	return health
+}
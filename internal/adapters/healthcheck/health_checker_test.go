@@ -0,0 +1,212 @@
+package healthcheck_test
+
+import (
+	"api-gateway/internal/adapters/healthcheck"
+	"api-gateway/internal/domain/entities"
+	"api-gateway/pkg/logger"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flippingServer answers its health checks with the status it is set to
+func flippingServer(t *testing.T, status *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newBackend(check *entities.HealthCheck, hosts ...string) *entities.Backend {
+	backend := &entities.Backend{Id: "orders", Host: hosts[0], HealthCheck: check}
+	for _, host := range hosts {
+		backend.Targets = append(backend.Targets, entities.Target{Host: host, Weight: 1})
+	}
+	return backend
+}
+
+func TestHealthChecker_Thresholds(t *testing.T) {
+	var firstStatus, secondStatus atomic.Int32
+	firstStatus.Store(http.StatusOK)
+	secondStatus.Store(http.StatusOK)
+	first := flippingServer(t, &firstStatus)
+	second := flippingServer(t, &secondStatus)
+
+	check := &entities.HealthCheck{
+		Path:               "/healthz",
+		Interval:           10 * time.Millisecond,
+		Timeout:            10 * time.Millisecond,
+		HealthyThreshold:   2,
+		UnhealthyThreshold: 3,
+	}
+	checker := healthcheck.NewHealthChecker([]*entities.Backend{newBackend(check, first.URL, second.URL)}, logger.New("test"))
+	require.True(t, checker.IsHealthy("orders", first.URL), "targets start healthy")
+
+	checker.Start(context.Background())
+	defer checker.Close()
+
+	secondStatus.Store(http.StatusServiceUnavailable)
+	assert.Eventually(t, func() bool { return !checker.IsHealthy("orders", second.URL) }, time.Second, 5*time.Millisecond)
+	assert.True(t, checker.IsHealthy("orders", first.URL))
+
+	status := checker.Status()
+	require.Len(t, status, 1)
+	assert.True(t, status[0].Healthy, "a backend is healthy while any target is")
+	assert.Equal(t, []string{first.URL, second.URL}, []string{status[0].Targets[0].Host, status[0].Targets[1].Host})
+	assert.Contains(t, status[0].Targets[1].LastError, "503")
+
+	firstStatus.Store(http.StatusInternalServerError)
+	assert.Eventually(t, func() bool { return !checker.Status()[0].Healthy }, time.Second, 5*time.Millisecond)
+
+	secondStatus.Store(http.StatusOK)
+	assert.Eventually(t, func() bool { return checker.IsHealthy("orders", second.URL) }, time.Second, 5*time.Millisecond)
+	assert.True(t, checker.Status()[0].Healthy)
+	assert.Empty(t, checker.Status()[0].Targets[1].LastError)
+}
+
+func TestHealthChecker_Probe(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer slow.Close()
+	var probes atomic.Int32
+	expected := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer expected.Close()
+
+	tests := []struct {
+		name        string
+		host        string
+		expected    []int
+		wantHealthy bool
+	}{
+		{name: "timeout", host: slow.URL, wantHealthy: false},
+		{name: "connection refused", host: "http://127.0.0.1:1", wantHealthy: false},
+		{name: "any 2xx", host: expected.URL, wantHealthy: true},
+		{name: "status not expected", host: expected.URL, expected: []int{http.StatusOK}, wantHealthy: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := &entities.HealthCheck{
+				Path:               "/healthz",
+				Interval:           time.Hour,
+				Timeout:            20 * time.Millisecond,
+				HealthyThreshold:   1,
+				UnhealthyThreshold: 1,
+				ExpectedStatuses:   tt.expected,
+			}
+			checker := healthcheck.NewHealthChecker([]*entities.Backend{newBackend(check, tt.host)}, logger.New("test"))
+			checker.Start(context.Background())
+			defer checker.Close()
+
+			// The first probe runs right away, failed ones leave their error behind
+			assert.Eventually(t, func() bool {
+				if tt.wantHealthy {
+					return probes.Load() > 0 && checker.Status()[0].Targets[0].LastError == ""
+				}
+				return !checker.IsHealthy("orders", tt.host) && checker.Status()[0].Targets[0].LastError != ""
+			}, time.Second, 5*time.Millisecond)
+			assert.Equal(t, tt.wantHealthy, checker.IsHealthy("orders", tt.host))
+		})
+	}
+}
+
+func TestHealthChecker_Unchecked(t *testing.T) {
+	checker := healthcheck.NewHealthChecker([]*entities.Backend{{Id: "users", Host: "http://users:8080"}}, logger.New("test"))
+	checker.Start(context.Background())
+	defer checker.Close()
+
+	assert.True(t, checker.IsHealthy("users", "http://users:8080"))
+	assert.True(t, checker.IsHealthy("unknown", "http://unknown:8080"))
+	assert.Empty(t, checker.Status())
+}
+
+func TestHealthChecker_OutlierDetection(t *testing.T) {
+	detection := &entities.OutlierDetection{
+		ConsecutiveErrors:  3,
+		BaseEjectionTime:   50 * time.Millisecond,
+		MaxEjectionTime:    time.Second,
+		MaxEjectionPercent: 50,
+	}
+	backend := newBackend(nil, "http://orders-1:8080", "http://orders-2:8080", "http://orders-3:8080")
+	backend.OutlierDetection = detection
+	checker := healthcheck.NewHealthChecker([]*entities.Backend{backend}, logger.New("test"))
+	checker.Start(context.Background())
+	defer checker.Close()
+
+	fail := func(host string, times int) {
+		for i := 0; i < times; i++ {
+			checker.Observe("orders", host, entities.TargetOutcomeFailure)
+		}
+	}
+
+	fail("http://orders-1:8080", 2)
+	checker.Observe("orders", "http://orders-1:8080", entities.TargetOutcomeSuccess)
+	checker.Observe("orders", "http://orders-1:8080", entities.TargetOutcomeIgnored)
+	fail("http://orders-1:8080", 2)
+	assert.True(t, checker.IsHealthy("orders", "http://orders-1:8080"), "a success breaks the run of errors")
+
+	fail("http://orders-1:8080", 1)
+	require.False(t, checker.IsHealthy("orders", "http://orders-1:8080"), "the third error in a row ejects the target")
+	status := checker.Status()[0]
+	assert.True(t, status.Healthy, "a backend is healthy while any target is")
+	assert.False(t, status.Targets[0].Healthy)
+	assert.WithinDuration(t, time.Now().Add(50*time.Millisecond), status.Targets[0].EjectedUntil, 20*time.Millisecond)
+
+	fail("http://orders-2:8080", 3)
+	assert.True(t, checker.IsHealthy("orders", "http://orders-2:8080"), "at most half of the targets, but one, are ejected")
+
+	assert.Eventually(t, func() bool { return checker.IsHealthy("orders", "http://orders-1:8080") }, time.Second, 5*time.Millisecond,
+		"an ejected target comes back after the ejection time")
+	assert.True(t, checker.Status()[0].Targets[0].EjectedUntil.IsZero())
+
+	fail("http://orders-1:8080", 3)
+	assert.WithinDuration(t, time.Now().Add(100*time.Millisecond), checker.Status()[0].Targets[0].EjectedUntil, 20*time.Millisecond,
+		"an ejection in a row lasts longer")
+}
+
+func TestHealthChecker_OutlierDetectionSingleTarget(t *testing.T) {
+	backend := newBackend(nil, "http://orders-1:8080")
+	backend.OutlierDetection = &entities.OutlierDetection{ConsecutiveErrors: 1, BaseEjectionTime: time.Minute, MaxEjectionTime: time.Minute, MaxEjectionPercent: 10}
+	checker := healthcheck.NewHealthChecker([]*entities.Backend{backend}, logger.New("test"))
+
+	checker.Observe("orders", "http://orders-1:8080", entities.TargetOutcomeFailure)
+	checker.Observe("orders", "http://orders-1:8080", entities.TargetOutcomeFailure)
+
+	assert.True(t, checker.IsHealthy("orders", "http://orders-1:8080"), "the only target is never ejected")
+	assert.True(t, checker.Status()[0].Healthy)
+}
+
+func TestHealthChecker_OutlierDetectionCountsUnhealthyTargets(t *testing.T) {
+	var downStatus, upStatus atomic.Int32
+	downStatus.Store(http.StatusServiceUnavailable)
+	upStatus.Store(http.StatusOK)
+	down, up := flippingServer(t, &downStatus), flippingServer(t, &upStatus)
+	check := &entities.HealthCheck{Path: "/healthz", Interval: time.Hour, Timeout: time.Second, HealthyThreshold: 1, UnhealthyThreshold: 1}
+	backend := newBackend(check, down.URL, up.URL)
+	backend.OutlierDetection = &entities.OutlierDetection{ConsecutiveErrors: 1, BaseEjectionTime: time.Minute, MaxEjectionTime: time.Minute, MaxEjectionPercent: 50}
+	checker := healthcheck.NewHealthChecker([]*entities.Backend{backend}, logger.New("test"))
+	checker.Start(context.Background())
+	defer checker.Close()
+
+	require.Eventually(t, func() bool { return !checker.IsHealthy("orders", down.URL) }, time.Second, 5*time.Millisecond)
+
+	checker.Observe("orders", up.URL, entities.TargetOutcomeFailure)
+
+	assert.True(t, checker.IsHealthy("orders", up.URL), "the target left after a failed health check is not ejected")
+}
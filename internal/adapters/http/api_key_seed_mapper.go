@@ -0,0 +1,44 @@
+package http
+
+import (
+	"api-gateway/internal/config"
+	"api-gateway/internal/domain/entities"
+	"fmt"
+	"time"
+)
+
+// toApiKeySeeds collects the keys declared under api_keys.seed and in the
+// api_keys.seed_file, in that order
+func toApiKeySeeds(cfg config.ApiKeysConfig) ([]*entities.ApiKey, error) {
+	seeds := cfg.Seed
+	if cfg.SeedFile != "" {
+		fileSeeds, err := config.LoadApiKeySeeds(cfg.SeedFile)
+		if err != nil {
+			return nil, err
+		}
+		seeds = append(seeds, fileSeeds...)
+	}
+
+	records := make([]*entities.ApiKey, 0, len(seeds))
+	for _, seed := range seeds {
+		record := &entities.ApiKey{
+			ID:         seed.ID,
+			Salt:       seed.Salt,
+			SecretHash: seed.SecretHash,
+			Owner:      seed.Owner,
+			Scopes:     seed.Scopes,
+			Roles:      seed.Roles,
+			Plan:       seed.Plan,
+			Disabled:   seed.Disabled,
+		}
+		if seed.ExpiresAt != "" {
+			expiresAt, err := time.Parse(time.RFC3339, seed.ExpiresAt)
+			if err != nil {
+				return nil, fmt.Errorf("api key seed %s: invalid expires_at: %w", seed.ID, err)
+			}
+			record.ExpiresAt = expiresAt
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,218 @@
+package http
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/config"
+	"api-gateway/internal/domain/entities"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// toAuthPolicy maps a route's auth_policy section onto the domain policy,
+// falling back to the backend-level jwt section when the route declares none
+func toAuthPolicy(policy *config.AuthPolicy, backend config.BackendServiceConfig) (*entities.AuthPolicy, error) {
+	if policy == nil {
+		return &entities.AuthPolicy{Type: entities.AuthTypeNone}, nil
+	}
+
+	authPolicy := &entities.AuthPolicy{
+		Enabled: policy.Enabled,
+		Type:    policy.Type,
+	}
+
+	if policy.Type == entities.AuthTypeJWT {
+		jwtConfig := policy.JWT
+		if jwtConfig == nil {
+			jwtConfig = backend.JWT
+		}
+		if jwtConfig != nil {
+			converted, err := toJWTConfig(jwtConfig)
+			if err != nil {
+				return nil, err
+			}
+			authPolicy.JWT = converted
+		}
+	}
+
+	if policy.Type == entities.AuthTypeOAuth2Introspection && policy.Introspection != nil {
+		authPolicy.Introspection = &entities.IntrospectionConfig{
+			Endpoint:         policy.Introspection.Endpoint,
+			ClientID:         policy.Introspection.ClientID,
+			ClientSecret:     policy.Introspection.ClientSecret,
+			RequiredScopes:   policy.Introspection.RequiredScopes,
+			CacheTTL:         policy.Introspection.CacheTTL,
+			NegativeCacheTTL: policy.Introspection.NegativeCacheTTL,
+			Timeout:          policy.Introspection.Timeout,
+		}
+	}
+
+	if policy.Type == entities.AuthTypeMTLS && policy.MTLS != nil {
+		converted, err := toMTLSConfig(policy.MTLS)
+		if err != nil {
+			return nil, err
+		}
+		authPolicy.MTLS = converted
+	}
+
+	if policy.Type == entities.AuthTypeHMAC && policy.HMAC != nil {
+		converted, err := toHMACConfig(policy.HMAC)
+		if err != nil {
+			return nil, err
+		}
+		authPolicy.HMAC = converted
+	}
+
+	if policy.Type == entities.AuthTypeBasic && policy.Basic != nil {
+		converted, err := toBasicAuthConfig(policy.Basic)
+		if err != nil {
+			return nil, err
+		}
+		authPolicy.Basic = converted
+	}
+
+	if policy.Type == entities.AuthTypeExternal && policy.External != nil {
+		authPolicy.External = &entities.ExternalAuthConfig{
+			Endpoint:       policy.External.Endpoint,
+			Timeout:        policy.External.Timeout,
+			CacheTTL:       policy.External.CacheTTL,
+			FailOpen:       policy.External.FailOpen,
+			ForwardHeaders: policy.External.ForwardHeaders,
+		}
+	}
+
+	if policy.Type == entities.AuthTypeComposite {
+		authPolicy.Match = policy.Match
+		for i, child := range policy.Policies {
+			child.Enabled = policy.Enabled
+			converted, err := toAuthPolicy(&child, backend)
+			if err != nil {
+				return nil, fmt.Errorf("policy %d: %w", i, err)
+			}
+			authPolicy.Policies = append(authPolicy.Policies, converted)
+		}
+	}
+
+	return authPolicy, nil
+}
+
+func toAuthorizationPolicy(authorization *config.AuthorizationConfig) (*entities.AuthorizationPolicy, error) {
+	if authorization == nil {
+		return nil, nil
+	}
+
+	policy := &entities.AuthorizationPolicy{
+		RequiredScopes: authorization.RequiredScopes,
+		RequiredRoles:  authorization.RequiredRoles,
+		Match:          authorization.Match,
+	}
+	if strings.TrimSpace(authorization.Rule) != "" {
+		rule, err := entities.NewAuthorizationRule(authorization.Rule)
+		if err != nil {
+			return nil, err
+		}
+		policy.Rule = rule
+	}
+	return policy, nil
+}
+
+func toJWTConfig(cfg *config.JWTConfig) (*entities.JWTConfig, error) {
+	jwtConfig := &entities.JWTConfig{
+		Issuer:    cfg.Issuer,
+		Audiences: cfg.Audiences,
+		ClockSkew: cfg.ClockSkew,
+	}
+
+	if cfg.JWKS != nil {
+		jwtConfig.JWKS = &entities.JWKSConfig{
+			URL:             cfg.JWKS.URL,
+			File:            cfg.JWKS.File,
+			RefreshInterval: cfg.JWKS.RefreshInterval,
+			CacheTTL:        cfg.JWKS.CacheTTL,
+		}
+	}
+
+	for _, key := range cfg.Keys {
+		publicKey := key.PublicKey
+		if publicKey == "" && key.PublicKeyFile != "" {
+			content, err := os.ReadFile(key.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read jwt public key file: %w", err)
+			}
+			publicKey = string(content)
+		}
+
+		jwtConfig.Keys = append(jwtConfig.Keys, entities.JWTKey{
+			ID:        key.ID,
+			Algorithm: key.Algorithm,
+			Secret:    key.Secret,
+			PublicKey: publicKey,
+		})
+	}
+
+	return jwtConfig, nil
+}
+
+func toHMACConfig(cfg *config.HMACConfig) (*entities.HMACConfig, error) {
+	hmacConfig := &entities.HMACConfig{
+		SignedHeaders: cfg.SignedHeaders,
+		MaxClockSkew:  cfg.MaxClockSkew,
+	}
+
+	for _, credential := range cfg.Credentials {
+		secret := credential.Secret
+		if secret == "" && credential.SecretFile != "" {
+			content, err := os.ReadFile(credential.SecretFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read hmac secret file: %w", err)
+			}
+			secret = strings.TrimSpace(string(content))
+		}
+
+		hmacConfig.Credentials = append(hmacConfig.Credentials, entities.HMACCredential{
+			ID:     credential.ID,
+			Secret: secret,
+			Owner:  credential.Owner,
+			Scopes: credential.Scopes,
+			Roles:  credential.Roles,
+		})
+	}
+
+	return hmacConfig, nil
+}
+
+func toMTLSConfig(cfg *config.MTLSConfig) (*entities.MTLSConfig, error) {
+	mtlsConfig := &entities.MTLSConfig{
+		Subjects: cfg.Subjects,
+		DNSNames: cfg.DNSNames,
+		URIs:     cfg.URIs,
+		Emails:   cfg.Emails,
+	}
+
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		mtlsConfig.ClientCAs = pool
+	}
+
+	return mtlsConfig, nil
+}
+
+func toBasicAuthConfig(cfg *config.BasicAuthConfig) (*entities.BasicAuthConfig, error) {
+	basicConfig := &entities.BasicAuthConfig{
+		Realm:  cfg.Realm,
+		Source: cfg.Source,
+	}
+
+	if cfg.HtpasswdFile != "" {
+		users, err := auth.LoadHtpasswd(cfg.HtpasswdFile)
+		if err != nil {
+			return nil, err
+		}
+		basicConfig.Users = users
+	}
+
+	return basicConfig, nil
+}
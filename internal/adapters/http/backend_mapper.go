@@ -0,0 +1,51 @@
+package http
+
+import (
+	"api-gateway/internal/config"
+	"api-gateway/internal/domain/entities"
+	"fmt"
+	"time"
+)
+
+// toBackend builds a backend from its config section. A single host becomes
+// the only target, so every backend is balanced the same way.
+func toBackend(cfg config.BackendServiceConfig) (*entities.Backend, error) {
+	if cfg.Host != "" && len(cfg.Targets) > 0 {
+		return nil, fmt.Errorf("backend %s: set either host or targets", cfg.ID)
+	}
+
+	backend := &entities.Backend{
+		Id:         cfg.ID,
+		Host:       cfg.Host,
+		PathPrefix: cfg.PathPrefix,
+		Timeout:    30 * time.Second,
+		LoadBalancing: entities.LoadBalancing{
+			Strategy:   cfg.LoadBalancer.Strategy,
+			HashHeader: cfg.LoadBalancer.HashHeader,
+			HashCookie: cfg.LoadBalancer.HashCookie,
+		},
+		ConcurrencyLimit: toConcurrencyLimit(cfg.Concurrency),
+		HealthCheck:      toHealthCheck(cfg.HealthCheck),
+		OutlierDetection: toOutlierDetection(cfg.OutlierDetection),
+	}
+	if backend.LoadBalancing.Strategy == "" {
+		backend.LoadBalancing.Strategy = entities.BalancerRoundRobin
+	}
+
+	for _, target := range cfg.Targets {
+		weight := target.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		backend.Targets = append(backend.Targets, entities.Target{Host: target.Host, Weight: weight})
+	}
+	if len(backend.Targets) == 0 {
+		backend.Targets = []entities.Target{{Host: cfg.Host, Weight: 1}}
+	}
+	backend.Host = backend.Targets[0].Host
+
+	if err := backend.Validate(); err != nil {
+		return nil, fmt.Errorf("backend %s: %w", cfg.ID, err)
+	}
+	return backend, nil
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"api-gateway/internal/config"
+	"api-gateway/internal/domain/entities"
+	"time"
+)
+
+// defaultQueueTimeout bounds how long a request waits for a backend slot when
+// the concurrency section sets no queue_timeout
+const defaultQueueTimeout = time.Second
+
+// toConcurrencyLimit resolves the concurrency section of a backend, nil when
+// requests to it are not limited
+func toConcurrencyLimit(cfg *config.ConcurrencyConfig) *entities.ConcurrencyLimit {
+	if cfg == nil {
+		return nil
+	}
+
+	limit := &entities.ConcurrencyLimit{
+		Algorithm:        cfg.Algorithm,
+		MaxRequests:      cfg.MaxRequests,
+		MinRequests:      cfg.MinRequests,
+		MaxQueue:         cfg.MaxQueue,
+		QueueTimeout:     cfg.QueueTimeout,
+		LatencyThreshold: cfg.LatencyThreshold,
+	}
+	if limit.Algorithm == "" {
+		limit.Algorithm = entities.ConcurrencyAlgorithmFixed
+	}
+	if limit.QueueTimeout == 0 {
+		limit.QueueTimeout = defaultQueueTimeout
+	}
+	if limit.IsAdaptive() && limit.MinRequests == 0 {
+		limit.MinRequests = 1
+	}
+	return limit
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"api-gateway/internal/application/dto"
+	"api-gateway/internal/application/usecases"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AdminKeyHandler exposes the API key lifecycle under /admin/keys
+type AdminKeyHandler struct {
+	log           logger.Logger
+	apiKeyUseCase usecases.ApiKeyManagementUseCases
+	quotaUseCase  usecases.QuotaUseCases
+}
+
+func NewAdminKeyHandler(log logger.Logger, apiKeyUseCase usecases.ApiKeyManagementUseCases, quotaUseCase usecases.QuotaUseCases) *AdminKeyHandler {
+	log.Info("Initializing admin key handler")
+
+	return &AdminKeyHandler{
+		log:           log.With("component", "admin_key_handler"),
+		apiKeyUseCase: apiKeyUseCase,
+		quotaUseCase:  quotaUseCase,
+	}
+}
+
+// Create issues a new key; the response is the only time the key is returned
+func (h *AdminKeyHandler) Create(c echo.Context) error {
+	var req dto.CreateApiKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, domainErrors.ErrInvalidApiKeyRequest)
+	}
+
+	response, err := h.apiKeyUseCase.Create(c.Request().Context(), actor(c), &req)
+	if err != nil {
+		return h.errorResponse(c, err)
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (h *AdminKeyHandler) List(c echo.Context) error {
+	response, err := h.apiKeyUseCase.List(c.Request().Context())
+	if err != nil {
+		return h.errorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *AdminKeyHandler) Get(c echo.Context) error {
+	response, err := h.apiKeyUseCase.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return h.errorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// Rotate replaces the secret of a key; the old key stops working immediately
+func (h *AdminKeyHandler) Rotate(c echo.Context) error {
+	response, err := h.apiKeyUseCase.Rotate(c.Request().Context(), actor(c), c.Param("id"))
+	if err != nil {
+		return h.errorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *AdminKeyHandler) Disable(c echo.Context) error {
+	response, err := h.apiKeyUseCase.Disable(c.Request().Context(), actor(c), c.Param("id"))
+	if err != nil {
+		return h.errorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *AdminKeyHandler) Revoke(c echo.Context) error {
+	if err := h.apiKeyUseCase.Revoke(c.Request().Context(), actor(c), c.Param("id")); err != nil {
+		return h.errorResponse(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Usage reports the requests a key made in the current quota windows of its plan
+func (h *AdminKeyHandler) Usage(c echo.Context) error {
+	response, err := h.quotaUseCase.Usage(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return h.errorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *AdminKeyHandler) errorResponse(c echo.Context, err error) error {
+	var domainErr *domainErrors.DomainError
+	switch {
+	case errors.Is(err, domainErrors.ErrApiKeyNotFound):
+		return c.JSON(http.StatusNotFound, domainErrors.ErrApiKeyNotFound)
+	case errors.As(err, &domainErr):
+		return c.JSON(http.StatusBadRequest, domainErr)
+	}
+
+	h.log.Error("Admin key operation failed",
+		"method", c.Request().Method,
+		"path", c.Request().URL.Path,
+		"error", err)
+	return c.JSON(http.StatusInternalServerError, domainErrors.NewValidationError("INTERNAL_ERROR", "admin key operation failed"))
+}
+
+func actor(c echo.Context) dto.ApiKeyActor {
+	return dto.ApiKeyActor{
+		Source:    "admin_api",
+		RequestID: c.Request().Header.Get(echo.HeaderXRequestID),
+		RemoteIP:  c.RealIP(),
+	}
+}
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"api-gateway/internal/adapters/http/middlewares/security"
 	"api-gateway/internal/application/dto"
 	"api-gateway/internal/application/usecases"
+	"api-gateway/internal/domain/entities"
 	domainErrors "api-gateway/internal/domain/errors"
 	"api-gateway/pkg/logger"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,15 +21,23 @@ type GatewayHandler struct {
 	log          logger.Logger
 	routeUseCase usecases.RouteRequestUseCases
 	authUseCase  usecases.AuthenticationUseCases
+	authzUseCase usecases.AuthorizationUseCases
+	rateLimit    usecases.RateLimitUseCases
+	quotas       usecases.QuotaUseCases
+	identity     *IdentityPropagator
 }
 
-func NewGatewayHandler(log logger.Logger, routeUseCase usecases.RouteRequestUseCases, authUseCase usecases.AuthenticationUseCases) *GatewayHandler {
+func NewGatewayHandler(log logger.Logger, routeUseCase usecases.RouteRequestUseCases, authUseCase usecases.AuthenticationUseCases, authzUseCase usecases.AuthorizationUseCases, rateLimit usecases.RateLimitUseCases, quotas usecases.QuotaUseCases, identity *IdentityPropagator) *GatewayHandler {
 	log.Info("Initializing gateway handler")
 
 	return &GatewayHandler{
 		log:          log,
 		routeUseCase: routeUseCase,
 		authUseCase:  authUseCase,
+		authzUseCase: authzUseCase,
+		rateLimit:    rateLimit,
+		quotas:       quotas,
+		identity:     identity,
 	}
 }
 
@@ -70,7 +81,7 @@ func (h *GatewayHandler) HandleRequest(c echo.Context) error {
 	gatewayRequestDto := dto.GatewayRequest{
 		Path:        c.Request().URL.Path,
 		Method:      c.Request().Method,
-		Headers:     c.Request().Header,
+		Headers:     c.Request().Header.Clone(),
 		Body:        body,
 		QueryParams: c.Request().URL.Query(),
 	}
@@ -117,6 +128,7 @@ func (h *GatewayHandler) HandleRequest(c echo.Context) error {
 
 	gatewayRequestDto.Host = route.Backend.Host + route.Backend.PathPrefix
 	gatewayRequestDto.Path = route.Path
+	gatewayRequestDto.Backend = route.Backend
 
 	if c.QueryString() != "" {
 		gatewayRequestDto.Path += "?" + c.QueryString()
@@ -128,9 +140,35 @@ func (h *GatewayHandler) HandleRequest(c echo.Context) error {
 		"backend_path", gatewayRequestDto.Path,
 	)
 
+	// Limits that do not depend on the caller are counted before authentication,
+	// so failed attempts use up the same buckets as any other request
+	if route.RateLimit != nil && !route.RateLimit.KeyedByConsumer() {
+		if h.rateLimited(ctx, c, route, nil) {
+			h.log.Info("Returning 429 Too Many Requests",
+				"request_id", requestID,
+				"route_id", route.ID,
+				"duration_ms", time.Since(startTime).Milliseconds(),
+			)
+			return c.JSON(http.StatusTooManyRequests, domainErrors.ErrRateLimitExceeded)
+		}
+	}
+
 	authRequest := dto.AuthRequest{
 		Headers: c.Request().Header,
 		Policy:  route.AuthPolicy,
+		Request: &entities.HTTPRequest{
+			RouteID:  route.ID,
+			Method:   c.Request().Method,
+			Host:     c.Request().Host,
+			Path:     c.Request().URL.EscapedPath(),
+			RawQuery: c.Request().URL.RawQuery,
+			Headers:  c.Request().Header,
+			Body:     body,
+		},
+	}
+	if state := c.Request().TLS; state != nil {
+		authRequest.Request.ClientCertificates = state.PeerCertificates
+		authRequest.Request.ClientCertificateVerified = len(state.VerifiedChains) > 0
 	}
 
 	h.log.Info("Starting authentication",
@@ -147,10 +185,35 @@ func (h *GatewayHandler) HandleRequest(c echo.Context) error {
 			"duration_ms", time.Since(startTime).Milliseconds(),
 		)
 
+		// Failed attempts count against the caller's address, the consumer
+		// they claimed to be is not known
+		if route.RateLimit != nil && route.RateLimit.KeyedByConsumer() && h.rateLimited(ctx, c, route, nil) {
+			h.log.Info("Returning 429 Too Many Requests - Failed authentication",
+				"request_id", requestID,
+				"route_id", route.ID,
+				"duration_ms", time.Since(startTime).Milliseconds(),
+			)
+			return c.JSON(http.StatusTooManyRequests, domainErrors.ErrRateLimitExceeded)
+		}
+
 		h.log.Info("Returning 401 Unauthorized - Invalid token",
 			"request_id", requestID,
 		)
 
+		if route.AuthPolicy != nil {
+			if basic := route.AuthPolicy.Find(entities.AuthTypeBasic); basic != nil {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, basic.Basic.Challenge())
+			}
+		}
+
+		if errors.Is(err, domainErrors.ErrExternalAuthorizationDenied) {
+			return c.JSON(http.StatusForbidden, domainErrors.ErrExternalAuthorizationDenied)
+		}
+
+		var domainErr *domainErrors.DomainError
+		if errors.As(err, &domainErr) {
+			return c.JSON(http.StatusUnauthorized, domainErr)
+		}
 		return c.JSON(http.StatusUnauthorized, domainErrors.NewValidationError("API_TOKEN_NOT_VALID", err.Error()))
 	}
 
@@ -158,6 +221,7 @@ func (h *GatewayHandler) HandleRequest(c echo.Context) error {
 		"request_id", requestID,
 		"authenticated", authResponse.Authenticated,
 		"user_id", authResponse.UserID,
+		"matched_policies", authResponse.MatchedPolicies,
 	)
 
 	h.log.Debug("Auth response details",
@@ -166,6 +230,66 @@ func (h *GatewayHandler) HandleRequest(c echo.Context) error {
 	)
 
 	if authResponse.Authenticated {
+		authzRequest := dto.AuthorizationRequest{
+			RouteID:    route.ID,
+			Policy:     route.Authorization,
+			Principal:  authResponse.Principal,
+			Request:    authRequest.Request,
+			PathParams: route.Params,
+		}
+
+		if err := h.authzUseCase.Execute(ctx, &authzRequest); err != nil {
+			h.log.Warn("Authorization failed",
+				"request_id", requestID,
+				"route_id", route.ID,
+				"user_id", authResponse.UserID,
+				"error", fmt.Sprintf("%v", err),
+				"duration_ms", time.Since(startTime).Milliseconds(),
+			)
+
+			h.log.Info("Returning 403 Forbidden",
+				"request_id", requestID,
+			)
+
+			var domainErr *domainErrors.DomainError
+			if errors.As(err, &domainErr) {
+				return c.JSON(http.StatusForbidden, domainErr)
+			}
+			return c.JSON(http.StatusForbidden, domainErrors.ErrForbidden)
+		}
+
+		if route.RateLimit != nil && route.RateLimit.KeyedByConsumer() && h.rateLimited(ctx, c, route, authResponse.Principal) {
+			h.log.Info("Returning 429 Too Many Requests",
+				"request_id", requestID,
+				"route_id", route.ID,
+				"user_id", authResponse.UserID,
+				"duration_ms", time.Since(startTime).Milliseconds(),
+			)
+			return c.JSON(http.StatusTooManyRequests, domainErrors.ErrRateLimitExceeded)
+		}
+
+		quota, err := h.quotas.Execute(ctx, authResponse.Principal)
+		security.WriteQuotaHeaders(c.Response().Header(), quota, time.Now())
+		if err != nil {
+			h.log.Info("Returning 429 Too Many Requests - Quota exceeded",
+				"request_id", requestID,
+				"route_id", route.ID,
+				"user_id", authResponse.UserID,
+				"plan", quota.Plan,
+				"duration_ms", time.Since(startTime).Milliseconds(),
+			)
+			return c.JSON(http.StatusTooManyRequests, domainErrors.ErrQuotaExceeded)
+		}
+
+		if err := h.identity.Apply(gatewayRequestDto.Headers, route.AuthPolicy, authResponse.Principal, route.Backend.Id); err != nil {
+			h.log.Error("Identity propagation failed",
+				"request_id", requestID,
+				"route_id", route.ID,
+				"error", err,
+			)
+			return c.JSON(http.StatusInternalServerError, domainErrors.NewValidationError("IDENTITY_PROPAGATION_FAILED", "Failed to forward caller identity"))
+		}
+
 		h.log.Info("User authenticated, executing route",
 			"request_id", requestID,
 			"backend_url", gatewayRequestDto.Host+gatewayRequestDto.Path,
@@ -180,6 +304,13 @@ func (h *GatewayHandler) HandleRequest(c echo.Context) error {
 				"backend_path", gatewayRequestDto.Path,
 				"duration_ms", time.Since(startTime).Milliseconds(),
 			)
+			if errors.Is(err, domainErrors.ErrBackendOverloaded) {
+				c.Response().Header().Set(security.HeaderRetryAfter, "1")
+				return c.JSON(http.StatusServiceUnavailable, domainErrors.ErrBackendOverloaded)
+			}
+			if errors.Is(err, domainErrors.ErrBackendUnavailable) {
+				return c.JSON(http.StatusServiceUnavailable, domainErrors.ErrBackendUnavailable)
+			}
 			return err
 		}
 
@@ -232,3 +363,16 @@ func (h *GatewayHandler) HandleRequest(c echo.Context) error {
 
 	return c.JSON(http.StatusUnauthorized, domainErrors.NewValidationError("NOT_UNAUTHENTICATED", "No authenticated user"))
 }
+
+// rateLimited counts the request against the route's rate limit, writes the
+// rate limit headers and reports whether the request has to be rejected
+func (h *GatewayHandler) rateLimited(ctx context.Context, c echo.Context, route *entities.Route, principal *entities.Principal) bool {
+	decision, err := h.rateLimit.Execute(ctx, &dto.RateLimitRequest{
+		RouteID:   route.ID,
+		Limit:     route.RateLimit,
+		Principal: principal,
+		ClientIP:  c.RealIP(),
+	})
+	security.WriteRateLimitHeaders(c.Response().Header(), decision)
+	return err != nil
+}
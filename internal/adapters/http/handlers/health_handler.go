@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"api-gateway/internal/application/ports"
 	"api-gateway/internal/infrastructure"
 	"context"
 	"net/http"
@@ -13,16 +14,18 @@ import (
 )
 
 type HealthHandler struct {
-	logger      logger.Logger
-	startTime   time.Time
-	connections *infrastructure.DatabaseConnections
+	logger        logger.Logger
+	startTime     time.Time
+	connections   *infrastructure.DatabaseConnections
+	healthChecker ports.HealthChecker
 }
 
-func NewHealthHandler(logger logger.Logger, connections *infrastructure.DatabaseConnections) *HealthHandler {
+func NewHealthHandler(logger logger.Logger, connections *infrastructure.DatabaseConnections, healthChecker ports.HealthChecker) *HealthHandler {
 	return &HealthHandler{
-		logger:      logger.With("component", "health_handler"),
-		startTime:   time.Now(),
-		connections: connections,
+		logger:        logger.With("component", "health_handler"),
+		startTime:     time.Now(),
+		connections:   connections,
+		healthChecker: healthChecker,
 	}
 }
 
@@ -116,6 +119,19 @@ func (h *HealthHandler) Ready(c echo.Context) error {
 		httpStatus = http.StatusServiceUnavailable
 	}
 
+	// Backends are reported without affecting readiness, the gateway still
+	// serves the other backends while one of them is down
+	if backends := h.healthChecker.Status(); len(backends) > 0 {
+		responseChecks["backends"] = backends
+		for _, backend := range backends {
+			if !backend.Healthy {
+				h.logger.Warn("Backend unhealthy during readiness check",
+					"backend_id", backend.BackendID,
+					"request_id", requestID)
+			}
+		}
+	}
+
 	response := HealthResponse{
 		Status:    status,
 		Timestamp: time.Now(),
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	"api-gateway/pkg/logger"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Headers that tell backends who the caller is. Any copies sent by clients
+// are removed before a request is forwarded, so backends can trust them.
+const (
+	HeaderGatewayPrefix        = "X-Gateway-"
+	HeaderGatewayUser          = "X-Gateway-User"
+	HeaderGatewayScopes        = "X-Gateway-Scopes"
+	HeaderGatewayRoles         = "X-Gateway-Roles"
+	HeaderGatewayKeyID         = "X-Gateway-Key-Id"
+	HeaderGatewayAuthType      = "X-Gateway-Auth-Type"
+	DefaultIdentityTokenHeader = "X-Gateway-Identity"
+)
+
+// IdentityOptions controls what backends learn about the authenticated caller
+type IdentityOptions struct {
+	// Headers injects the X-Gateway-* identity headers
+	Headers bool
+	// StripCredentials removes the credentials the route's auth policy consumed
+	StripCredentials bool
+	// Signer issues a signed identity token sent in TokenHeader; nil disables it
+	Signer      ports.IdentitySigner
+	TokenHeader string
+}
+
+// IdentityPropagator rewrites the headers forwarded to a backend
+type IdentityPropagator struct {
+	opts IdentityOptions
+	log  logger.Logger
+}
+
+func NewIdentityPropagator(log logger.Logger, opts IdentityOptions) *IdentityPropagator {
+	if opts.TokenHeader == "" {
+		opts.TokenHeader = DefaultIdentityTokenHeader
+	}
+	return &IdentityPropagator{
+		opts: opts,
+		log:  log.With("component", "identity"),
+	}
+}
+
+// Apply removes spoofed identity headers and consumed credentials, adds the
+// identity of the principal, if the request was authenticated, and finally
+// applies the header changes an external authorization service asked for
+func (p *IdentityPropagator) Apply(headers http.Header, policy *entities.AuthPolicy, principal *entities.Principal, audience string) error {
+	for name := range headers {
+		if strings.HasPrefix(http.CanonicalHeaderKey(name), HeaderGatewayPrefix) {
+			delete(headers, name)
+		}
+	}
+	headers.Del(p.opts.TokenHeader)
+
+	if policy != nil && policy.RequiresAuth() && p.opts.StripCredentials {
+		for _, name := range credentialHeaders(policy) {
+			headers.Del(name)
+		}
+	}
+
+	if principal == nil {
+		return nil
+	}
+
+	if p.opts.Headers {
+		setHeader(headers, HeaderGatewayUser, principal.Subject)
+		setHeader(headers, HeaderGatewayScopes, strings.Join(principal.Scopes, " "))
+		setHeader(headers, HeaderGatewayRoles, strings.Join(principal.Roles, ","))
+		setHeader(headers, HeaderGatewayKeyID, principal.KeyID)
+		setHeader(headers, HeaderGatewayAuthType, principal.AuthType)
+	}
+
+	if p.opts.Signer != nil {
+		token, err := p.opts.Signer.Sign(principal, audience)
+		if err != nil {
+			p.log.Error("Failed to sign identity token", "subject", principal.Subject, "audience", audience, "error", err)
+			return fmt.Errorf("failed to sign identity token: %w", err)
+		}
+		headers.Set(p.opts.TokenHeader, token)
+	}
+
+	if upstream := principal.Upstream; upstream != nil {
+		for _, name := range upstream.Remove {
+			headers.Del(name)
+		}
+		for name, value := range upstream.Set {
+			headers.Set(name, value)
+		}
+	}
+	return nil
+}
+
+// credentialHeaders lists the headers that carry the credentials of a policy
+// and of every policy of a composite
+func credentialHeaders(policy *entities.AuthPolicy) []string {
+	switch policy.Type {
+	case entities.AuthTypeAPIKey:
+		return []string{"X-Api-Key"}
+	case entities.AuthTypeJWT, entities.AuthTypeOAuth2Introspection, entities.AuthTypeBasic:
+		return []string{"Authorization"}
+	case entities.AuthTypeHMAC:
+		return []string{"Authorization", "X-Signature-Timestamp", "X-Signature-Nonce"}
+	case entities.AuthTypeComposite:
+		var names []string
+		for _, member := range policy.Policies {
+			names = append(names, credentialHeaders(member)...)
+		}
+		return names
+	}
+	return nil
+}
+
+func setHeader(headers http.Header, name, value string) {
+	if value != "" {
+		headers.Set(name, value)
+	}
+}
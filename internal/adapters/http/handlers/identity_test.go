@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"api-gateway/internal/adapters/http/handlers"
+	"api-gateway/internal/domain/entities"
+	"api-gateway/pkg/logger"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubSigner struct {
+	audience string
+	err      error
+}
+
+func (s *stubSigner) Sign(principal *entities.Principal, audience string) (string, error) {
+	s.audience = audience
+	return "signed-for-" + principal.Subject, s.err
+}
+
+func clientHeaders() http.Header {
+	return http.Header{
+		"X-Api-Key":        {"k1.secret"},
+		"Authorization":    {"Bearer backend-token"},
+		"X-Gateway-User":   {"admin"},
+		"X-Gateway-Roles":  {"superuser"},
+		"X-Gateway-Custom": {"spoofed"},
+		"Content-Type":     {"application/json"},
+	}
+}
+
+func TestIdentityPropagator_Apply(t *testing.T) {
+	apiKeyPolicy := &entities.AuthPolicy{Type: entities.AuthTypeAPIKey, Enabled: true}
+	principal := &entities.Principal{Subject: "billing", AuthType: entities.AuthTypeAPIKey, KeyID: "k1", Scopes: []string{"orders:read", "orders:write"}}
+
+	t.Run("public route keeps credentials but drops spoofed identity", func(t *testing.T) {
+		headers := clientHeaders()
+		propagator := handlers.NewIdentityPropagator(logger.New("test"), handlers.IdentityOptions{Headers: true, StripCredentials: true})
+
+		require.NoError(t, propagator.Apply(headers, &entities.AuthPolicy{Type: entities.AuthTypeNone}, nil, "orders"))
+
+		assert.Equal(t, http.Header{
+			"X-Api-Key":     {"k1.secret"},
+			"Authorization": {"Bearer backend-token"},
+			"Content-Type":  {"application/json"},
+		}, headers)
+	})
+
+	t.Run("authenticated route forwards identity instead of the key", func(t *testing.T) {
+		headers := clientHeaders()
+		propagator := handlers.NewIdentityPropagator(logger.New("test"), handlers.IdentityOptions{Headers: true, StripCredentials: true})
+
+		require.NoError(t, propagator.Apply(headers, apiKeyPolicy, principal, "orders"))
+
+		assert.Empty(t, headers.Get("X-Api-Key"))
+		assert.Equal(t, "Bearer backend-token", headers.Get("Authorization"))
+		assert.Equal(t, "billing", headers.Get(handlers.HeaderGatewayUser))
+		assert.Equal(t, "orders:read orders:write", headers.Get(handlers.HeaderGatewayScopes))
+		assert.Equal(t, "k1", headers.Get(handlers.HeaderGatewayKeyID))
+		assert.Equal(t, entities.AuthTypeAPIKey, headers.Get(handlers.HeaderGatewayAuthType))
+		assert.Empty(t, headers.Values(handlers.HeaderGatewayRoles))
+		assert.Empty(t, headers.Values("X-Gateway-Custom"))
+	})
+
+	t.Run("composite strips the credentials of every member", func(t *testing.T) {
+		headers := clientHeaders()
+		propagator := handlers.NewIdentityPropagator(logger.New("test"), handlers.IdentityOptions{StripCredentials: true})
+		composite := &entities.AuthPolicy{
+			Type:     entities.AuthTypeComposite,
+			Enabled:  true,
+			Policies: []*entities.AuthPolicy{{Type: entities.AuthTypeJWT, Enabled: true}, apiKeyPolicy},
+		}
+
+		require.NoError(t, propagator.Apply(headers, composite, principal, "orders"))
+
+		assert.Empty(t, headers.Get("X-Api-Key"))
+		assert.Empty(t, headers.Get("Authorization"))
+		assert.Empty(t, headers.Get(handlers.HeaderGatewayUser))
+	})
+
+	t.Run("signed identity token", func(t *testing.T) {
+		headers := clientHeaders()
+		headers.Set(handlers.DefaultIdentityTokenHeader, "forged")
+		signer := &stubSigner{}
+		propagator := handlers.NewIdentityPropagator(logger.New("test"), handlers.IdentityOptions{Signer: signer})
+
+		require.NoError(t, propagator.Apply(headers, apiKeyPolicy, principal, "orders"))
+
+		assert.Equal(t, "signed-for-billing", headers.Get(handlers.DefaultIdentityTokenHeader))
+		assert.Equal(t, "orders", signer.audience)
+		assert.Equal(t, "k1.secret", headers.Get("X-Api-Key"))
+	})
+
+	t.Run("external authorization header changes", func(t *testing.T) {
+		headers := clientHeaders()
+		propagator := handlers.NewIdentityPropagator(logger.New("test"), handlers.IdentityOptions{Headers: true})
+		external := &entities.Principal{
+			Subject:  "compliance",
+			AuthType: entities.AuthTypeExternal,
+			Upstream: &entities.HeaderMutation{
+				Set:    map[string]string{"X-Data-Classification": "restricted"},
+				Remove: []string{"Content-Type"},
+			},
+		}
+
+		require.NoError(t, propagator.Apply(headers, &entities.AuthPolicy{Type: entities.AuthTypeExternal, Enabled: true}, external, "reports"))
+
+		assert.Equal(t, "restricted", headers.Get("X-Data-Classification"))
+		assert.Empty(t, headers.Get("Content-Type"))
+		assert.Equal(t, "compliance", headers.Get(handlers.HeaderGatewayUser))
+	})
+
+	t.Run("signing failure", func(t *testing.T) {
+		propagator := handlers.NewIdentityPropagator(logger.New("test"), handlers.IdentityOptions{Signer: &stubSigner{err: errors.New("boom")}})
+
+		assert.Error(t, propagator.Apply(clientHeaders(), apiKeyPolicy, principal, "orders"))
+	})
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"api-gateway/internal/config"
+	"api-gateway/internal/domain/entities"
+	"time"
+)
+
+// Health check settings used when the section leaves them out
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 2 * time.Second
+	defaultHealthyThreshold    = 2
+	defaultUnhealthyThreshold  = 3
+)
+
+// toHealthCheck resolves the health_check section of a backend, nil when its
+// targets are not probed
+func toHealthCheck(cfg *config.HealthCheckConfig) *entities.HealthCheck {
+	if cfg == nil {
+		return nil
+	}
+
+	check := &entities.HealthCheck{
+		Path:               cfg.Path,
+		Interval:           cfg.Interval,
+		Timeout:            cfg.Timeout,
+		HealthyThreshold:   cfg.HealthyThreshold,
+		UnhealthyThreshold: cfg.UnhealthyThreshold,
+		ExpectedStatuses:   cfg.ExpectedStatus,
+	}
+	if check.Interval == 0 {
+		check.Interval = defaultHealthCheckInterval
+	}
+	if check.Timeout == 0 {
+		check.Timeout = min(defaultHealthCheckTimeout, check.Interval)
+	}
+	if check.HealthyThreshold == 0 {
+		check.HealthyThreshold = defaultHealthyThreshold
+	}
+	if check.UnhealthyThreshold == 0 {
+		check.UnhealthyThreshold = defaultUnhealthyThreshold
+	}
+	return check
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/adapters/http/handlers"
+	"api-gateway/internal/config"
+	"fmt"
+	"os"
+)
+
+// toIdentityOptions builds the identity propagation settings, including the
+// token signer when identity.token is enabled
+func toIdentityOptions(cfg config.IdentityConfig) (handlers.IdentityOptions, error) {
+	opts := handlers.IdentityOptions{
+		Headers:          cfg.Headers,
+		StripCredentials: cfg.StripCredentials,
+		TokenHeader:      cfg.Token.Header,
+	}
+	if !cfg.Token.Enabled {
+		return opts, nil
+	}
+
+	var privateKey string
+	if cfg.Token.PrivateKeyFile != "" {
+		content, err := os.ReadFile(cfg.Token.PrivateKeyFile)
+		if err != nil {
+			return opts, fmt.Errorf("failed to read identity token private key: %w", err)
+		}
+		privateKey = string(content)
+	}
+
+	signer, err := auth.NewIdentitySigner(auth.IdentitySignerOptions{
+		Issuer:     cfg.Token.Issuer,
+		TTL:        cfg.Token.TTL,
+		Algorithm:  cfg.Token.Algorithm,
+		KeyID:      cfg.Token.KeyID,
+		Secret:     cfg.Token.Secret,
+		PrivateKey: privateKey,
+	})
+	if err != nil {
+		return opts, fmt.Errorf("identity.token: %w", err)
+	}
+	opts.Signer = signer
+	return opts, nil
+}
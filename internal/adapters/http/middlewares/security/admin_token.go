@@ -0,0 +1,29 @@
+package security
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AdminToken only lets requests through that carry "Authorization: Bearer <token>"
+// with the configured admin token
+func AdminToken(logger logger.Logger, token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			scheme, presented, _ := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+			if !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+				logger.Warn("Rejected admin request",
+					"method", c.Request().Method,
+					"path", c.Request().URL.Path,
+					"remote_ip", c.RealIP())
+				return c.JSON(http.StatusUnauthorized, domainErrors.ErrAdminUnauthorized)
+			}
+			return next(c)
+		}
+	}
+}
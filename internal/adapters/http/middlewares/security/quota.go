@@ -0,0 +1,42 @@
+package security
+
+import (
+	"api-gateway/internal/domain/entities"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Quota response headers, one set per limited period of the plan, e.g.
+// X-Quota-Daily-Remaining. Reset is in seconds like RateLimit-Reset.
+const (
+	headerQuotaPrefix    = "X-Quota-"
+	HeaderQuotaPlan      = "X-Quota-Plan"
+	headerQuotaLimit     = "-Limit"
+	headerQuotaRemaining = "-Remaining"
+	headerQuotaReset     = "-Reset"
+)
+
+var quotaPeriodHeaders = map[entities.QuotaPeriod]string{
+	entities.QuotaPeriodDaily:   "Daily",
+	entities.QuotaPeriodMonthly: "Monthly",
+}
+
+// WriteQuotaHeaders tells the client how much of its plan's quotas is left,
+// and when it was rejected, how long to wait for the exhausted quota to reset
+func WriteQuotaHeaders(headers http.Header, decision *entities.QuotaDecision, now time.Time) {
+	if decision == nil {
+		return
+	}
+
+	headers.Set(HeaderQuotaPlan, decision.Plan)
+	for _, usage := range decision.Usage {
+		prefix := headerQuotaPrefix + quotaPeriodHeaders[usage.Period]
+		headers.Set(prefix+headerQuotaLimit, strconv.FormatInt(usage.Limit, 10))
+		headers.Set(prefix+headerQuotaRemaining, strconv.FormatInt(usage.Remaining(), 10))
+		headers.Set(prefix+headerQuotaReset, strconv.Itoa(ceilSeconds(usage.ResetAt.Sub(now))))
+	}
+	if !decision.Allowed {
+		headers.Set(HeaderRetryAfter, strconv.Itoa(max(1, ceilSeconds(decision.RetryAt().Sub(now)))))
+	}
+}
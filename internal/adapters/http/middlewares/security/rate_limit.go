@@ -1 +1,36 @@
 package security
+
+import (
+	"api-gateway/internal/domain/entities"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Rate limit response headers, following the IETF RateLimit header fields draft
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// WriteRateLimitHeaders tells the client how much of its limit is left, and
+// when it was rejected, how long to wait before retrying
+func WriteRateLimitHeaders(headers http.Header, decision *entities.RateLimitDecision) {
+	if decision == nil {
+		return
+	}
+
+	headers.Set(HeaderRateLimitLimit, strconv.Itoa(decision.Limit))
+	headers.Set(HeaderRateLimitRemaining, strconv.Itoa(decision.Remaining))
+	headers.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(decision.ResetAfter)))
+	if !decision.Allowed {
+		headers.Set(HeaderRetryAfter, strconv.Itoa(max(1, ceilSeconds(decision.RetryAfter))))
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"api-gateway/internal/config"
+	"api-gateway/internal/domain/entities"
+	"time"
+)
+
+// Outlier detection settings used when the section leaves them out
+const (
+	defaultConsecutiveErrors  = 5
+	defaultBaseEjectionTime   = 30 * time.Second
+	defaultMaxEjectionTime    = 300 * time.Second
+	defaultMaxEjectionPercent = 50
+)
+
+// toOutlierDetection resolves the outlier_detection section of a backend, nil
+// when its targets are never ejected
+func toOutlierDetection(cfg *config.OutlierDetectionConfig) *entities.OutlierDetection {
+	if cfg == nil {
+		return nil
+	}
+
+	detection := &entities.OutlierDetection{
+		ConsecutiveErrors:  cfg.ConsecutiveErrors,
+		BaseEjectionTime:   cfg.BaseEjectionTime,
+		MaxEjectionTime:    cfg.MaxEjectionTime,
+		MaxEjectionPercent: cfg.MaxEjectionPercent,
+	}
+	if detection.ConsecutiveErrors == 0 {
+		detection.ConsecutiveErrors = defaultConsecutiveErrors
+	}
+	if detection.BaseEjectionTime == 0 {
+		detection.BaseEjectionTime = defaultBaseEjectionTime
+	}
+	if detection.MaxEjectionTime == 0 {
+		detection.MaxEjectionTime = max(defaultMaxEjectionTime, detection.BaseEjectionTime)
+	}
+	if detection.MaxEjectionPercent == 0 {
+		detection.MaxEjectionPercent = defaultMaxEjectionPercent
+	}
+	return detection
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"api-gateway/internal/config"
+	"api-gateway/internal/domain/entities"
+	"fmt"
+	"time"
+)
+
+// toQuotaPolicy builds the quota plans from the quotas section, nil when
+// quotas are disabled
+func toQuotaPolicy(cfg config.QuotasConfig) (*entities.QuotaPolicy, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("quotas: invalid timezone %q: %w", cfg.Timezone, err)
+	}
+
+	policy := &entities.QuotaPolicy{
+		Plans:       make(map[string]*entities.QuotaPlan, len(cfg.Plans)),
+		DefaultPlan: cfg.DefaultPlan,
+		Location:    location,
+	}
+	for name, plan := range cfg.Plans {
+		policy.Plans[name] = &entities.QuotaPlan{Name: name, Daily: plan.Daily, Monthly: plan.Monthly}
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("quotas: %w", err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"api-gateway/internal/config"
+	"api-gateway/internal/domain/entities"
+	"math"
+)
+
+// defaultRateLimitName is shared by every route without a rate_limit section,
+// so the default limit counts requests across those routes together
+const defaultRateLimitName = "default"
+
+// toRateLimit resolves the limit of a route from its rate_limit section,
+// falling back to the security defaults for anything it leaves unset
+func toRateLimit(route config.RouteConfig, security config.SecurityConfig) *entities.RateLimit {
+	if route.RateLimit == nil {
+		if security.RateLimitRPS <= 0 {
+			return nil
+		}
+		return &entities.RateLimit{
+			Name:  defaultRateLimitName,
+			Rate:  float64(security.RateLimitRPS),
+			Burst: defaultBurst(float64(security.RateLimitRPS), security.RateLimitBurst),
+			KeyBy: security.RateLimitKeyBy,
+		}
+	}
+	if route.RateLimit.Disabled {
+		return nil
+	}
+
+	limit := &entities.RateLimit{
+		Name:  route.ID,
+		Rate:  route.RateLimit.RequestsPerSecond,
+		Burst: route.RateLimit.Burst,
+		KeyBy: route.RateLimit.KeyBy,
+	}
+	if limit.Rate == 0 {
+		limit.Rate = float64(security.RateLimitRPS)
+		if limit.Burst == 0 {
+			limit.Burst = security.RateLimitBurst
+		}
+	}
+	limit.Burst = defaultBurst(limit.Rate, limit.Burst)
+	if limit.KeyBy == "" {
+		limit.KeyBy = security.RateLimitKeyBy
+	}
+	return limit
+}
+
+// defaultBurst allows at least one second worth of requests when no burst is set
+func defaultBurst(rate float64, burst int) int {
+	if burst > 0 {
+		return burst
+	}
+	return max(1, int(math.Ceil(rate)))
+}
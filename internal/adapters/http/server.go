@@ -2,17 +2,23 @@ package http
 
 import (
 	"api-gateway/internal/adapters/auth"
+	"api-gateway/internal/adapters/balancer"
+	"api-gateway/internal/adapters/healthcheck"
 	"api-gateway/internal/adapters/http/handlers"
 	"api-gateway/internal/adapters/http/middlewares/logging"
 	"api-gateway/internal/adapters/http/middlewares/security"
 	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/application/dto"
 	"api-gateway/internal/application/usecases"
 	"api-gateway/internal/config"
 	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
 	"api-gateway/internal/infrastructure"
 	"api-gateway/pkg/logger"
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -25,6 +31,8 @@ type Server struct {
 	config      *config.Config
 	logger      logger.Logger
 	connections *infrastructure.DatabaseConnections
+	closers     []io.Closer
+	tlsConfig   *tls.Config
 }
 
 func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructure.DatabaseConnections) (*Server, error) {
@@ -33,6 +41,12 @@ func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructur
 	// Configure Echo
 	e.HideBanner = true
 	e.HidePort = true
+	// Client IPs key rate limits, so forwarded headers are only trusted on request
+	if cfg.Security.TrustForwardedFor {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	} else {
+		e.IPExtractor = echo.ExtractIPDirect()
+	}
 
 	server := &Server{
 		echo:        e,
@@ -41,6 +55,20 @@ func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructur
 		connections: connections,
 	}
 
+	// Seed configured API keys before accepting traffic
+	if err := server.seedApiKeys(cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.Server.TLS.Enabled {
+		tlsConfig, reloader, err := NewTLSConfig(cfg.Server.TLS, log)
+		if err != nil {
+			return nil, err
+		}
+		server.tlsConfig = tlsConfig
+		server.closers = append(server.closers, reloader)
+	}
+
 	// Setup middleware
 	server.setupMiddleware()
 
@@ -50,6 +78,30 @@ func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructur
 	return server, nil
 }
 
+// seedApiKeys stores the pre-hashed keys from the api_keys config section or
+// seed file. Keys that exist or were revoked are left untouched.
+func (s *Server) seedApiKeys(cfg *config.Config) error {
+	seeds, err := toApiKeySeeds(cfg.ApiKeys)
+	if err != nil {
+		return fmt.Errorf("failed to load api key seeds: %w", err)
+	}
+	if len(seeds) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	apiKeyUseCase := usecases.NewApiKeyManagementUseCase(s.connections.GetApiKeyRepo(), s.logger)
+	seeded, err := apiKeyUseCase.Seed(ctx, dto.ApiKeyActor{Source: "bootstrap"}, seeds)
+	if err != nil {
+		return fmt.Errorf("failed to seed api keys: %w", err)
+	}
+
+	s.logger.Info("API key bootstrap completed", "configured", len(seeds), "seeded", seeded)
+	return nil
+}
+
 func (s *Server) setupMiddleware() {
 	// Replace Echo's logger with our custom Zap logger
 	s.echo.Use(logging.ZapLogger(s.logger.With("component", "http")))
@@ -78,9 +130,16 @@ func (s *Server) setupMiddleware() {
 
 func (s *Server) setupRoutes(cfg *config.Config) {
 	// Health check handlers with database connections
-	routes := s.parseRoutes(cfg)
+	routes, err := s.parseRoutes(cfg)
+	if err != nil {
+		s.logger.Fatal("failed to parse routes", zap.Error(err))
+		return
+	}
 	ctx := context.Background()
-	healthHandler := handlers.NewHealthHandler(s.logger, s.connections)
+	healthChecker := healthcheck.NewHealthChecker(routeBackends(routes), s.logger)
+	healthChecker.Start(ctx)
+	s.closers = append(s.closers, healthChecker)
+	healthHandler := handlers.NewHealthHandler(s.logger, s.connections, healthChecker)
 	proxyClientRepo := handlers.NewProxyClient(60*time.Second, s.logger)
 	memoryRouteRepo := repositories.NewMemoryRouteRepo(s.logger)
 	for _, route := range routes {
@@ -90,10 +149,30 @@ func (s *Server) setupRoutes(cfg *config.Config) {
 			return
 		}
 	}
-	authValidator := auth.NewAuthValidator(s.logger, s.connections.GetApiKeyRepo())
+	if closer, ok := s.connections.GetApiKeyRepo().(io.Closer); ok {
+		s.closers = append(s.closers, closer)
+	}
+	authValidator := auth.NewAuthValidator(s.logger, s.connections.GetApiKeyRepo(), s.connections.GetNonceStore(), cfg.Identity.Token.Header)
+	if closer, ok := authValidator.(io.Closer); ok {
+		s.closers = append(s.closers, closer)
+	}
 	authUseCase := usecases.NewAuthenticateRequestUseCase(authValidator, s.logger)
-	routeUseCase := usecases.NewRouteRequestUseCase(cfg.Server.PathPrefix, proxyClientRepo, memoryRouteRepo, s.logger)
-	gatewayHandler := handlers.NewGatewayHandler(s.logger, routeUseCase, authUseCase)
+	authzUseCase := usecases.NewAuthorizeRequestUseCase(s.logger)
+	rateLimitUseCase := usecases.NewRateLimitRequestUseCase(s.connections.GetRateLimiter(), s.logger)
+	quotaPolicy, err := toQuotaPolicy(cfg.Quotas)
+	if err != nil {
+		s.logger.Fatal("failed to configure usage quotas", zap.Error(err))
+		return
+	}
+	quotaUseCase := usecases.NewQuotaUseCase(quotaPolicy, s.connections.GetQuotaStore(), s.connections.GetApiKeyRepo(), s.logger)
+	routeUseCase := usecases.NewRouteRequestUseCase(cfg.Server.PathPrefix, proxyClientRepo, memoryRouteRepo, repositories.NewMemoryConcurrencyLimiter(), balancer.NewLoadBalancer(healthChecker), s.logger)
+	identityOptions, err := toIdentityOptions(cfg.Identity)
+	if err != nil {
+		s.logger.Fatal("failed to configure identity propagation", zap.Error(err))
+		return
+	}
+	identity := handlers.NewIdentityPropagator(s.logger, identityOptions)
+	gatewayHandler := handlers.NewGatewayHandler(s.logger, routeUseCase, authUseCase, authzUseCase, rateLimitUseCase, quotaUseCase, identity)
 	// API routes
 	api := s.echo.Group(cfg.Server.PathPrefix)
 	health := api.Group("/health")
@@ -108,9 +187,34 @@ func (s *Server) setupRoutes(cfg *config.Config) {
 
 	api.Any("/*", gatewayHandler.HandleRequest, security.RequestID(s.logger.With("component", "security")))
 
+	if cfg.Admin.Enabled {
+		s.setupAdminRoutes(cfg, quotaUseCase)
+	}
+
 	s.logRegisteredRoutes()
 }
 
+// setupAdminRoutes registers the API key lifecycle endpoints under /admin
+func (s *Server) setupAdminRoutes(cfg *config.Config, quotaUseCase usecases.QuotaUseCases) {
+	if cfg.Admin.Token == "" {
+		s.logger.Fatal("failed to enable admin api", zap.Error(domainErrors.ErrAdminMissingToken))
+		return
+	}
+
+	apiKeyUseCase := usecases.NewApiKeyManagementUseCase(s.connections.GetApiKeyRepo(), s.logger)
+	adminKeyHandler := handlers.NewAdminKeyHandler(s.logger, apiKeyUseCase, quotaUseCase)
+
+	admin := s.echo.Group("/admin", security.AdminToken(s.logger.With("component", "security"), cfg.Admin.Token))
+	keys := admin.Group("/keys")
+	keys.POST("", adminKeyHandler.Create)
+	keys.GET("", adminKeyHandler.List)
+	keys.GET("/:id", adminKeyHandler.Get)
+	keys.GET("/:id/usage", adminKeyHandler.Usage)
+	keys.POST("/:id/rotate", adminKeyHandler.Rotate)
+	keys.POST("/:id/disable", adminKeyHandler.Disable)
+	keys.DELETE("/:id", adminKeyHandler.Revoke)
+}
+
 func (s *Server) logRegisteredRoutes() {
 	s.logger.Info("HTTP routes registered:")
 	for _, route := range s.echo.Routes() {
@@ -123,38 +227,78 @@ func (s *Server) logRegisteredRoutes() {
 
 func (s *Server) Start() error {
 	address := fmt.Sprintf("%s:%s", s.config.Server.Host, s.config.Server.Port)
-	s.logger.Info("Starting Product Service HTTP server", "address", address)
+	if s.tlsConfig != nil {
+		s.logger.Info("Starting Product Service HTTPS server", "address", address, "client_auth", s.config.Server.TLS.ClientAuth)
+		s.echo.TLSServer.Addr = address
+		s.echo.TLSServer.TLSConfig = s.tlsConfig
+		return s.echo.StartServer(s.echo.TLSServer)
+	}
 
+	s.logger.Info("Starting Product Service HTTP server", "address", address)
 	return s.echo.Start(address)
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down Product Service HTTP server...")
-	return s.echo.Shutdown(ctx)
+	err := s.echo.Shutdown(ctx)
+
+	for _, closer := range s.closers {
+		if closeErr := closer.Close(); closeErr != nil {
+			s.logger.Warn("Failed to release server resource", "error", closeErr)
+		}
+	}
+	return err
 }
 
-func (s *Server) parseRoutes(cfg *config.Config) []entities.Route {
+func (s *Server) parseRoutes(cfg *config.Config) ([]entities.Route, error) {
 	var routes []entities.Route
 	for _, backend := range cfg.Backends {
-		entityBackend := entities.Backend{
-			Id:         backend.ID,
-			Host:       backend.Host,
-			PathPrefix: backend.PathPrefix,
-			Timeout:    30 * time.Second,
+		entityBackend, err := toBackend(backend)
+		if err != nil {
+			return nil, err
 		}
 		for _, route := range backend.Routes {
+			authPolicy, err := toAuthPolicy(route.AuthPolicy, backend)
+			if err != nil {
+				return nil, fmt.Errorf("route %s: %w", route.ID, err)
+			}
+			if authPolicy.Find(entities.AuthTypeMTLS) != nil && authPolicy.Enabled && !requestsClientCertificates(cfg.Server.TLS) {
+				return nil, fmt.Errorf("route %s: mtls policy requires server.tls with a client_auth other than none", route.ID)
+			}
+			authorization, err := toAuthorizationPolicy(route.Authorization)
+			if err != nil {
+				return nil, fmt.Errorf("route %s: %w", route.ID, err)
+			}
 			routes = append(routes, entities.Route{
-				ID:       route.ID,
-				Method:   route.Method,
-				Path:     route.Path,
-				PathType: entities.PathType(route.PathType),
-				Enabled:  route.Enabled,
-				Backend:  &entityBackend,
-				AuthPolicy: &entities.AuthPolicy{
-					Enabled: route.AuthPolicy.Enabled,
-					Type:    route.AuthPolicy.Type,
-				}})
+				ID:            route.ID,
+				Method:        route.Method,
+				Path:          route.Path,
+				PathType:      entities.PathType(route.PathType),
+				Enabled:       route.Enabled,
+				Backend:       entityBackend,
+				AuthPolicy:    authPolicy,
+				Authorization: authorization,
+				RateLimit:     toRateLimit(route, cfg.Security),
+			})
+		}
+	}
+	return routes, nil
+}
+
+// routeBackends returns each backend the routes point at once
+func routeBackends(routes []entities.Route) []*entities.Backend {
+	var backends []*entities.Backend
+	seen := make(map[string]bool)
+	for _, route := range routes {
+		if !seen[route.Backend.Id] {
+			seen[route.Backend.Id] = true
+			backends = append(backends, route.Backend)
 		}
 	}
-	return routes
+	return backends
+}
+
+// requestsClientCertificates reports whether the listener asks clients for a certificate at all
+func requestsClientCertificates(cfg config.TLSConfig) bool {
+	return cfg.Enabled && cfg.ClientAuth != "" && cfg.ClientAuth != config.ClientAuthNone
 }
@@ -0,0 +1,221 @@
+package http
+
+import (
+	"api-gateway/internal/config"
+	"api-gateway/pkg/logger"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// certificateReloadDebounce lets a certificate and key that are replaced one
+// after the other settle before they are loaded as a pair
+const certificateReloadDebounce = 200 * time.Millisecond
+
+// NewTLSConfig builds the listener TLS configuration. The returned reloader
+// must be closed to stop watching the certificate and key for changes.
+func NewTLSConfig(cfg config.TLSConfig, log logger.Logger) (*tls.Config, *CertificateReloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, nil, errors.New("server.tls requires cert_file and key_file")
+	}
+
+	minVersion, err := tlsVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	cipherSuites, err := tlsCipherSuites(cfg.CipherSuites)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientAuth, err := tlsClientAuth(cfg.ClientAuth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   minVersion,
+		CipherSuites: cipherSuites,
+		ClientAuth:   clientAuth,
+	}
+
+	if cfg.ClientCAFile != "" {
+		pool, err := loadCertPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		tlsConfig.ClientCAs = pool
+	}
+	if tlsConfig.ClientAuth >= tls.VerifyClientCertIfGiven && tlsConfig.ClientCAs == nil {
+		return nil, nil, fmt.Errorf("server.tls.client_auth %s requires client_ca_file", cfg.ClientAuth)
+	}
+
+	reloader, err := NewCertificateReloader(cfg.CertFile, cfg.KeyFile, log)
+	if err != nil {
+		return nil, nil, err
+	}
+	tlsConfig.GetCertificate = reloader.GetCertificate
+
+	return tlsConfig, reloader, nil
+}
+
+// CertificateReloader serves the listener certificate and replaces it when
+// the certificate or key file changes. A pair that fails to load is logged
+// and the previous certificate stays in use.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+	current  atomic.Pointer[tls.Certificate]
+	watcher  *fsnotify.Watcher
+	done     chan struct{}
+	log      logger.Logger
+}
+
+func NewCertificateReloader(certFile, keyFile string, log logger.Logger) (*CertificateReloader, error) {
+	reloader := &CertificateReloader{
+		certFile: filepath.Clean(certFile),
+		keyFile:  filepath.Clean(keyFile),
+		done:     make(chan struct{}),
+		log:      log.With("component", "tls"),
+	}
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch tls certificate: %w", err)
+	}
+	// Watch the directories, certificate tooling usually replaces files instead of writing to them
+	for _, dir := range []string{filepath.Dir(reloader.certFile), filepath.Dir(reloader.keyFile)} {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("failed to watch tls certificate: %w", err)
+		}
+	}
+	reloader.watcher = watcher
+	go reloader.watch()
+
+	return reloader, nil
+}
+
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.current.Load(), nil
+}
+
+// Close stops watching the certificate and key
+func (r *CertificateReloader) Close() error {
+	close(r.done)
+	return r.watcher.Close()
+}
+
+func (r *CertificateReloader) watch() {
+	debounce := time.NewTimer(certificateReloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			name := filepath.Clean(event.Name)
+			if name != r.certFile && name != r.keyFile {
+				continue
+			}
+			if event.Has(fsnotify.Write | fsnotify.Create | fsnotify.Rename) {
+				debounce.Reset(certificateReloadDebounce)
+			}
+		case <-debounce.C:
+			if err := r.reload(); err != nil {
+				r.log.Error("Failed to reload TLS certificate, keeping the previous one", "error", err)
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			r.log.Error("TLS certificate watcher failed", "error", err)
+		}
+	}
+}
+
+func (r *CertificateReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load tls certificate: %w", err)
+	}
+
+	r.current.Store(&cert)
+	if leaf := cert.Leaf; leaf != nil {
+		r.log.Info("TLS certificate loaded", "subject", leaf.Subject.String(), "not_after", leaf.NotAfter)
+	}
+	return nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("no certificates found in ca bundle %s", path)
+	}
+	return pool, nil
+}
+
+func tlsVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported server.tls.min_version %q, expected 1.2 or 1.3", version)
+}
+
+// tlsCipherSuites resolves cipher suite names; suites Go considers insecure are rejected
+func tlsCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	available := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		available[suite.Name] = suite.ID
+	}
+
+	suites := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := available[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported or insecure cipher suite %q", name)
+		}
+		suites = append(suites, id)
+	}
+	return suites, nil
+}
+
+func tlsClientAuth(mode string) (tls.ClientAuthType, error) {
+	switch mode {
+	case "", config.ClientAuthNone:
+		return tls.NoClientCert, nil
+	case config.ClientAuthRequest:
+		return tls.RequestClientCert, nil
+	case config.ClientAuthVerifyIfGiven:
+		return tls.VerifyClientCertIfGiven, nil
+	case config.ClientAuthRequireAndVerify:
+		return tls.RequireAndVerifyClientCert, nil
+	}
+	return tls.NoClientCert, fmt.Errorf("unsupported server.tls.client_auth %q", mode)
+}
@@ -0,0 +1,157 @@
+package http_test
+
+import (
+	gatewayHttp "api-gateway/internal/adapters/http"
+	"api-gateway/internal/config"
+	"api-gateway/pkg/logger"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate issues a certificate for name, signed by parent or self-signed when parent is nil,
+// and writes it and its key as PEM files into dir
+func writeCertificate(t *testing.T, dir, name string, serial int64, parent *tls.Certificate, isCA bool) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, any(key)
+	if parent != nil {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".crt"), certPEM, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"), keyPEM, 0o600))
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestCertificateReloader_ReloadsChangedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	writeCertificate(t, dir, "gateway", 1, nil, false)
+	certFile, keyFile := filepath.Join(dir, "gateway.crt"), filepath.Join(dir, "gateway.key")
+
+	reloader, err := gatewayHttp.NewCertificateReloader(certFile, keyFile, logger.New("test"))
+	require.NoError(t, err)
+	defer reloader.Close()
+
+	serial := func() int64 {
+		cert, _ := reloader.GetCertificate(nil)
+		return cert.Leaf.SerialNumber.Int64()
+	}
+	assert.Equal(t, int64(1), serial())
+
+	writeCertificate(t, dir, "gateway", 2, nil, false)
+	assert.Eventually(t, func() bool { return serial() == 2 }, 3*time.Second, 50*time.Millisecond)
+
+	// A broken pair is ignored and the last good certificate keeps serving
+	require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0o600))
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, int64(2), serial())
+}
+
+func TestNewTLSConfig_RequiresClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := writeCertificate(t, dir, "client-ca", 1, nil, true)
+	writeCertificate(t, dir, "gateway", 2, &ca, false)
+	client := writeCertificate(t, dir, "partner", 3, &ca, false)
+
+	tlsConfig, reloader, err := gatewayHttp.NewTLSConfig(config.TLSConfig{
+		Enabled:      true,
+		CertFile:     filepath.Join(dir, "gateway.crt"),
+		KeyFile:      filepath.Join(dir, "gateway.key"),
+		ClientCAFile: filepath.Join(dir, "client-ca.crt"),
+		ClientAuth:   config.ClientAuthRequireAndVerify,
+	}, logger.New("test"))
+	require.NoError(t, err)
+	defer reloader.Close()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+			ServerName:   "gateway",
+		}}}
+	}
+
+	resp, err := newClient(client).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = newClient().Get(server.URL)
+	assert.Error(t, err)
+}
+
+func TestNewTLSConfig_RejectsInvalidSettings(t *testing.T) {
+	dir := t.TempDir()
+	writeCertificate(t, dir, "gateway", 1, nil, false)
+	base := config.TLSConfig{
+		Enabled:  true,
+		CertFile: filepath.Join(dir, "gateway.crt"),
+		KeyFile:  filepath.Join(dir, "gateway.key"),
+	}
+
+	tests := []struct {
+		name   string
+		modify func(cfg *config.TLSConfig)
+	}{
+		{"missing key", func(cfg *config.TLSConfig) { cfg.KeyFile = "" }},
+		{"unknown min version", func(cfg *config.TLSConfig) { cfg.MinVersion = "1.0" }},
+		{"insecure cipher suite", func(cfg *config.TLSConfig) { cfg.CipherSuites = []string{"TLS_RSA_WITH_RC4_128_SHA"} }},
+		{"unknown client auth", func(cfg *config.TLSConfig) { cfg.ClientAuth = "always" }},
+		{"verification without ca", func(cfg *config.TLSConfig) { cfg.ClientAuth = config.ClientAuthRequireAndVerify }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			tt.modify(&cfg)
+			_, _, err := gatewayHttp.NewTLSConfig(cfg, logger.New("test"))
+			assert.Error(t, err)
+		})
+	}
+}
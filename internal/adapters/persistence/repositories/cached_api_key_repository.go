@@ -0,0 +1,336 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"container/list"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	defaultApiKeyCacheSize        = 10000
+	defaultApiKeyCacheTTL         = 15 * time.Second
+	defaultApiKeyCacheNegativeTTL = 5 * time.Second
+	// apiKeyUsageTimeout bounds last use updates, they run outside of requests
+	apiKeyUsageTimeout = 2 * time.Second
+)
+
+// ApiKeyCacheOptions bounds the key cache. Zero values use the defaults.
+type ApiKeyCacheOptions struct {
+	// Size is the maximum number of cached key ids
+	Size int
+	// TTL is how long a stored record is served from the cache
+	TTL time.Duration
+	// NegativeTTL is how long an unknown id is remembered as unknown
+	NegativeTTL time.Duration
+	// UsageInterval is how often last use is written to the store per key, defaults to TTL
+	UsageInterval time.Duration
+}
+
+// apiKeyCacheEntry caches the stored record of an id, or nil when the id does not exist
+type apiKeyCacheEntry struct {
+	id        string
+	record    *entities.ApiKey
+	expiresAt time.Time
+	// usageRecordedAt is when last use was last written to the store
+	usageRecordedAt time.Time
+}
+
+// CachedApiKeyRepository serves key lookups from a bounded in-process LRU in
+// front of another store. Records are cached by id and the secret is verified
+// on every lookup, so wrong secrets for a known id never reach the store.
+// Changes made through this instance drop the cached copy right away, changes
+// made elsewhere are picked up from stores that notify about them, or once the
+// entry expires.
+type CachedApiKeyRepository struct {
+	ports.ApiKeyRepository
+
+	opts ApiKeyCacheOptions
+	log  logger.Logger
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	// generation changes on every invalidation, lookups started before it
+	// must not cache what they loaded
+	generation uint64
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewCachedApiKeyRepository(repo ports.ApiKeyRepository, opts ApiKeyCacheOptions, log logger.Logger) ports.ApiKeyRepository {
+	if opts.Size <= 0 {
+		opts.Size = defaultApiKeyCacheSize
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaultApiKeyCacheTTL
+	}
+	if opts.NegativeTTL <= 0 {
+		opts.NegativeTTL = defaultApiKeyCacheNegativeTTL
+	}
+	if opts.UsageInterval <= 0 {
+		opts.UsageInterval = opts.TTL
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cache := &CachedApiKeyRepository{
+		ApiKeyRepository: repo,
+		opts:             opts,
+		log:              log.With("component", "api_key_cache"),
+		entries:          make(map[string]*list.Element),
+		order:            list.New(),
+		cancel:           cancel,
+		done:             make(chan struct{}),
+	}
+
+	if notifier, ok := repo.(ports.ApiKeyChangeNotifier); ok {
+		go cache.watchChanges(ctx, notifier)
+	} else {
+		close(cache.done)
+	}
+	return cache
+}
+
+// IsValidKey checks if an API key exists, is enabled and has not expired
+func (c *CachedApiKeyRepository) IsValidKey(ctx context.Context, key string) (bool, error) {
+	record, err := c.FindKey(ctx, key)
+	if errors.Is(err, domainErrors.ErrApiKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := record.CheckUsable(time.Now()); err != nil {
+		return false, nil
+	}
+
+	c.RecordKeyUsage(ctx, record.ID)
+	return true, nil
+}
+
+// FindKey returns the record of an API key if its secret matches, loading
+// the record from the underlying store on a cache miss
+func (c *CachedApiKeyRepository) FindKey(ctx context.Context, key string) (*entities.ApiKey, error) {
+	id, secret := entities.ParseApiKey(key)
+
+	record, ok := c.cached(id)
+	if !ok {
+		var err error
+		record, err = c.load(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if record == nil || !record.VerifySecret(secret) {
+		return nil, domainErrors.ErrApiKeyNotFound
+	}
+	return record, nil
+}
+
+// GetKeyMetadata serves the metadata of a key from the cached record, quota
+// plans are looked up on every request
+func (c *CachedApiKeyRepository) GetKeyMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	record, ok := c.cached(id)
+	if !ok {
+		var err error
+		record, err = c.load(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if record == nil {
+		return nil, domainErrors.ErrApiKeyNotFound
+	}
+	return apiKeyMetadata(record), nil
+}
+
+// RecordKeyUsage forwards last use to the store at most once per usage
+// interval per key and without blocking the request. Only cached ids are
+// recorded, the interval could not be kept for the others.
+func (c *CachedApiKeyRepository) RecordKeyUsage(ctx context.Context, id string) {
+	recorder, ok := c.ApiKeyRepository.(ports.ApiKeyUsageRecorder)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	element, ok := c.entries[id]
+	if !ok {
+		c.mu.Unlock()
+		return
+	}
+	entry := element.Value.(*apiKeyCacheEntry)
+	if now.Sub(entry.usageRecordedAt) < c.opts.UsageInterval {
+		c.mu.Unlock()
+		return
+	}
+	entry.usageRecordedAt = now
+	c.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), apiKeyUsageTimeout)
+		defer cancel()
+		recorder.RecordKeyUsage(ctx, id)
+	}()
+}
+
+func (c *CachedApiKeyRepository) StoreKey(ctx context.Context, key string, record *entities.ApiKey) error {
+	err := c.ApiKeyRepository.StoreKey(ctx, key, record)
+	// The id may be cached as unknown from an earlier lookup
+	c.forget(record.ID)
+	return err
+}
+
+func (c *CachedApiKeyRepository) SeedKey(ctx context.Context, record *entities.ApiKey) (bool, error) {
+	stored, err := c.ApiKeyRepository.SeedKey(ctx, record)
+	c.forget(record.ID)
+	return stored, err
+}
+
+func (c *CachedApiKeyRepository) UpdateKey(ctx context.Context, record *entities.ApiKey) error {
+	err := c.ApiKeyRepository.UpdateKey(ctx, record)
+	c.forget(record.ID)
+	return err
+}
+
+func (c *CachedApiKeyRepository) RevokeKey(ctx context.Context, id string) error {
+	err := c.ApiKeyRepository.RevokeKey(ctx, id)
+	c.forget(id)
+	return err
+}
+
+// Close stops watching for changes and closes the underlying store
+func (c *CachedApiKeyRepository) Close() error {
+	c.cancel()
+	<-c.done
+
+	if closer, ok := c.ApiKeyRepository.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// cached returns the cached record of an id; a nil record with ok set means
+// the id is known not to exist
+func (c *CachedApiKeyRepository) cached(id string) (*entities.ApiKey, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*apiKeyCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, id)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	if entry.record == nil {
+		return nil, true
+	}
+	return cloneApiKey(entry.record), true
+}
+
+// load reads an id from the underlying store and caches the result. Unknown
+// ids are cached for the negative TTL, store failures are not cached.
+func (c *CachedApiKeyRepository) load(ctx context.Context, id string) (*entities.ApiKey, error) {
+	c.mu.Lock()
+	generation := c.generation
+	c.mu.Unlock()
+
+	record, err := c.ApiKeyRepository.GetKey(ctx, id)
+	if err != nil && !errors.Is(err, domainErrors.ErrApiKeyNotFound) {
+		return nil, err
+	}
+
+	ttl := c.opts.TTL
+	if record == nil {
+		ttl = c.opts.NegativeTTL
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// An invalidation arrived while loading, what we loaded may be stale already
+	if c.generation != generation {
+		return record, nil
+	}
+
+	entry := &apiKeyCacheEntry{id: id, expiresAt: time.Now().Add(ttl)}
+	if record != nil {
+		entry.record = cloneApiKey(record)
+	}
+	if element, ok := c.entries[id]; ok {
+		c.order.Remove(element)
+	}
+	c.entries[id] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.opts.Size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*apiKeyCacheEntry).id)
+	}
+	return record, nil
+}
+
+// invalidate drops the cached copy of an id, or every entry for an empty id
+func (c *CachedApiKeyRepository) invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if id == "" {
+		c.entries = make(map[string]*list.Element)
+		c.order.Init()
+		return
+	}
+	if element, ok := c.entries[id]; ok {
+		c.order.Remove(element)
+		delete(c.entries, id)
+	}
+}
+
+// forget drops the cached copy of a key changed through this instance
+func (c *CachedApiKeyRepository) forget(id string) {
+	if id != "" {
+		c.invalidate(id)
+	}
+}
+
+// watchChanges keeps invalidating entries for changes reported by the store,
+// resubscribing with a backoff when the subscription fails
+func (c *CachedApiKeyRepository) watchChanges(ctx context.Context, notifier ports.ApiKeyChangeNotifier) {
+	defer close(c.done)
+
+	backoff := time.Second
+	for {
+		err := notifier.WatchKeyChanges(ctx, c.invalidate)
+		if ctx.Err() != nil {
+			return
+		}
+		c.log.Warn("API key change subscription ended, resubscribing", "error", err, "retry_in", backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package repositories_test
+
+import (
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingApiKeyRepository counts record loads and usage writes that reach the wrapped store
+type countingApiKeyRepository struct {
+	ports.ApiKeyRepository
+	loads  int32
+	usages int32
+}
+
+func (r *countingApiKeyRepository) GetKey(ctx context.Context, id string) (*entities.ApiKey, error) {
+	atomic.AddInt32(&r.loads, 1)
+	return r.ApiKeyRepository.GetKey(ctx, id)
+}
+
+func (r *countingApiKeyRepository) RecordKeyUsage(ctx context.Context, id string) {
+	atomic.AddInt32(&r.usages, 1)
+}
+
+func newCachedRepo(t *testing.T, opts repositories.ApiKeyCacheOptions) (ports.ApiKeyRepository, *countingApiKeyRepository) {
+	t.Helper()
+	inner := &countingApiKeyRepository{ApiKeyRepository: repositories.NewMemoryApiKeyRepository(logger.New("test"))}
+	cache := repositories.NewCachedApiKeyRepository(inner, opts, logger.New("test"))
+	t.Cleanup(func() { cache.(io.Closer).Close() })
+	return cache, inner
+}
+
+func TestCachedApiKeyRepository_ServesRepeatedLookups(t *testing.T) {
+	cache, inner := newCachedRepo(t, repositories.ApiKeyCacheOptions{})
+	ctx := context.Background()
+	require.NoError(t, cache.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing"}))
+
+	for i := 0; i < 3; i++ {
+		record, err := cache.FindKey(ctx, "k1.one")
+		require.NoError(t, err)
+		assert.Equal(t, "billing", record.Owner)
+	}
+	_, err := cache.FindKey(ctx, "k1.wrong")
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&inner.loads))
+}
+
+func TestCachedApiKeyRepository_ServesMetadata(t *testing.T) {
+	cache, inner := newCachedRepo(t, repositories.ApiKeyCacheOptions{})
+	ctx := context.Background()
+	require.NoError(t, cache.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing", Plan: "pro"}))
+
+	for i := 0; i < 3; i++ {
+		metadata, err := cache.GetKeyMetadata(ctx, "k1")
+		require.NoError(t, err)
+		assert.Equal(t, "pro", metadata["plan"])
+		assert.NotContains(t, metadata, "salt")
+		assert.NotContains(t, metadata, "secret_hash")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&inner.loads))
+
+	_, err := cache.GetKeyMetadata(ctx, "missing")
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+}
+
+func TestCachedApiKeyRepository_NegativeEntries(t *testing.T) {
+	cache, inner := newCachedRepo(t, repositories.ApiKeyCacheOptions{NegativeTTL: 50 * time.Millisecond})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		valid, err := cache.IsValidKey(ctx, "k1.one")
+		require.NoError(t, err)
+		assert.False(t, valid)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&inner.loads))
+
+	time.Sleep(60 * time.Millisecond)
+	_, err := cache.FindKey(ctx, "k1.one")
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&inner.loads), "unknown ids are reloaded after the negative TTL")
+
+	require.NoError(t, cache.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing"}))
+	valid, err := cache.IsValidKey(ctx, "k1.one")
+	require.NoError(t, err)
+	assert.True(t, valid, "storing a key drops the negative entry")
+}
+
+func TestCachedApiKeyRepository_ThrottlesUsage(t *testing.T) {
+	cache, inner := newCachedRepo(t, repositories.ApiKeyCacheOptions{Size: 1, UsageInterval: time.Minute})
+	ctx := context.Background()
+	require.NoError(t, cache.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing"}))
+	require.NoError(t, cache.StoreKey(ctx, "k2.two", &entities.ApiKey{Owner: "billing"}))
+	recorder := cache.(ports.ApiKeyUsageRecorder)
+
+	for i := 0; i < 3; i++ {
+		valid, err := cache.IsValidKey(ctx, "k1.one")
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&inner.usages) == 1 }, time.Second, 5*time.Millisecond)
+
+	// k2 evicts k1, usage of ids no longer cached is not written at all
+	_, err := cache.FindKey(ctx, "k2.two")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		recorder.RecordKeyUsage(ctx, "k1")
+		recorder.RecordKeyUsage(ctx, "unknown")
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&inner.usages))
+}
+
+func TestCachedApiKeyRepository_MutationsInvalidate(t *testing.T) {
+	cache, _ := newCachedRepo(t, repositories.ApiKeyCacheOptions{})
+	ctx := context.Background()
+	require.NoError(t, cache.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing"}))
+
+	record, err := cache.FindKey(ctx, "k1.one")
+	require.NoError(t, err)
+
+	record.Disabled = true
+	require.NoError(t, cache.UpdateKey(ctx, record))
+	valid, err := cache.IsValidKey(ctx, "k1.one")
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	require.NoError(t, cache.RevokeKey(ctx, "k1"))
+	_, err = cache.FindKey(ctx, "k1.one")
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+}
+
+func TestCachedApiKeyRepository_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache, inner := newCachedRepo(t, repositories.ApiKeyCacheOptions{Size: 2})
+	ctx := context.Background()
+	for _, key := range []string{"k1.one", "k2.two", "k3.three"} {
+		require.NoError(t, cache.StoreKey(ctx, key, &entities.ApiKey{Owner: "billing"}))
+	}
+
+	tests := []struct {
+		key   string
+		loads int32
+	}{
+		{"k1.one", 1},
+		{"k2.two", 2},
+		{"k1.one", 2},
+		{"k3.three", 3}, // evicts k2
+		{"k1.one", 3},
+		{"k2.two", 4},
+	}
+	for _, tt := range tests {
+		_, err := cache.FindKey(ctx, tt.key)
+		require.NoError(t, err)
+		assert.Equal(t, tt.loads, atomic.LoadInt32(&inner.loads), tt.key)
+	}
+}
+
+func TestCachedApiKeyRepository_InvalidatesAcrossInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	newInstance := func() ports.ApiKeyRepository {
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		cache := repositories.NewCachedApiKeyRepository(repositories.NewRedisApiKeyRepository(client, logger.New("test")),
+			repositories.ApiKeyCacheOptions{TTL: time.Hour}, logger.New("test"))
+		t.Cleanup(func() { cache.(io.Closer).Close() })
+		return cache
+	}
+	ctx := context.Background()
+
+	admin := newInstance()
+	gateway := newInstance()
+	require.NoError(t, admin.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing"}))
+
+	assert.Eventually(t, func() bool {
+		valid, _ := gateway.IsValidKey(ctx, "k1.one")
+		return valid
+	}, 2*time.Second, 20*time.Millisecond)
+
+	require.NoError(t, admin.RevokeKey(ctx, "k1"))
+
+	assert.Eventually(t, func() bool {
+		valid, _ := gateway.IsValidKey(ctx, "k1.one")
+		return !valid
+	}, 2*time.Second, 20*time.Millisecond, "revocations reach other instances before the TTL")
+}
@@ -0,0 +1,342 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	"api-gateway/pkg/logger"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.yaml.in/yaml/v3"
+)
+
+// fileReloadDebounce is how long the key file must stay unchanged before it is reloaded
+const fileReloadDebounce = 100 * time.Millisecond
+
+// apiKeyFile is the layout of a key file. It uses the same entries as an
+// api_keys seed file, so a seed file can be used as a key store directly.
+type apiKeyFile struct {
+	Keys    []apiKeyFileEntry `json:"keys" yaml:"keys"`
+	Revoked []string          `json:"revoked,omitempty" yaml:"revoked,omitempty"`
+}
+
+type apiKeyFileEntry struct {
+	ID         string   `json:"id" yaml:"id"`
+	Salt       string   `json:"salt" yaml:"salt"`
+	SecretHash string   `json:"secret_hash" yaml:"secret_hash"`
+	Owner      string   `json:"owner" yaml:"owner"`
+	Scopes     []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	Roles      []string `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Plan       string   `json:"plan,omitempty" yaml:"plan,omitempty"`
+	CreatedAt  string   `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	ExpiresAt  string   `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	Disabled   bool     `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// FileApiKeyRepository serves API keys from a YAML or JSON file and reloads it
+// when it changes on disk. Mutations made through the admin API or CLI are
+// written back to the file; last use timestamps are only kept in memory.
+type FileApiKeyRepository struct {
+	*MemoryApiKeyRepository
+
+	path string
+	// writeMu serializes mutations with reloads, so a reload can never drop a
+	// change that is not written to the file yet
+	writeMu sync.Mutex
+	// lastContent is the file content last loaded or written, repeated events
+	// for the same content are skipped
+	lastContent []byte
+	watcher     *fsnotify.Watcher
+	done        chan struct{}
+	log         logger.Logger
+}
+
+func NewFileApiKeyRepository(path string, log logger.Logger) (ports.ApiKeyRepository, error) {
+	repo := &FileApiKeyRepository{
+		MemoryApiKeyRepository: newMemoryApiKeyRepository(log),
+		path:                   filepath.Clean(path),
+		done:                   make(chan struct{}),
+		log:                    log,
+	}
+
+	if err := repo.reload(); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		log.Warn("API key file does not exist yet, starting without keys", "path", repo.path)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch api key file: %w", err)
+	}
+	// Watch the directory, editors and config management usually replace the file instead of writing to it
+	if err := watcher.Add(filepath.Dir(repo.path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch api key file: %w", err)
+	}
+	repo.watcher = watcher
+	go repo.watch()
+
+	return repo, nil
+}
+
+// HealthCheck verifies the key file can still be read
+func (r *FileApiKeyRepository) HealthCheck(ctx context.Context) error {
+	if _, err := os.Stat(r.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("api key file check failed: %w", err)
+	}
+	return nil
+}
+
+func (r *FileApiKeyRepository) StoreKey(ctx context.Context, key string, record *entities.ApiKey) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	if err := r.MemoryApiKeyRepository.StoreKey(ctx, key, record); err != nil {
+		return err
+	}
+	return r.persist()
+}
+
+func (r *FileApiKeyRepository) SeedKey(ctx context.Context, record *entities.ApiKey) (bool, error) {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	stored, err := r.MemoryApiKeyRepository.SeedKey(ctx, record)
+	if err != nil || !stored {
+		return stored, err
+	}
+	return true, r.persist()
+}
+
+func (r *FileApiKeyRepository) UpdateKey(ctx context.Context, record *entities.ApiKey) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	if err := r.MemoryApiKeyRepository.UpdateKey(ctx, record); err != nil {
+		return err
+	}
+	return r.persist()
+}
+
+func (r *FileApiKeyRepository) RevokeKey(ctx context.Context, id string) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	if err := r.MemoryApiKeyRepository.RevokeKey(ctx, id); err != nil {
+		return err
+	}
+	return r.persist()
+}
+
+// Close stops watching the key file
+func (r *FileApiKeyRepository) Close() error {
+	close(r.done)
+	return r.watcher.Close()
+}
+
+func (r *FileApiKeyRepository) watch() {
+	// In-place writes truncate the file before writing it, reloading on the
+	// first event could see an empty file. Wait until events settle.
+	debounce := time.NewTimer(fileReloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != r.path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				continue
+			}
+			debounce.Reset(fileReloadDebounce)
+		case <-debounce.C:
+			if err := r.reload(); err != nil {
+				// Keep serving the last good set of keys
+				r.log.Error("Failed to reload API key file", "path", r.path, "error", err)
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			r.log.Error("API key file watcher failed", "path", r.path, "error", err)
+		}
+	}
+}
+
+// reload replaces the keys with the file contents, keeping last use timestamps.
+// Events caused by our own writes are skipped.
+func (r *FileApiKeyRepository) reload() error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, r.lastContent) {
+		return nil
+	}
+
+	var file apiKeyFile
+	if err := r.unmarshal(data, &file); err != nil {
+		return fmt.Errorf("invalid api key file %s: %w", r.path, err)
+	}
+
+	keys := make(map[string]*entities.ApiKey, len(file.Keys))
+	for _, entry := range file.Keys {
+		record, err := apiKeyFromFileEntry(entry)
+		if err != nil {
+			return fmt.Errorf("invalid api key %s in %s: %w", entry.ID, r.path, err)
+		}
+		if err := record.Validate(); err != nil {
+			return fmt.Errorf("invalid api key %s in %s: %w", entry.ID, r.path, err)
+		}
+		keys[record.ID] = record
+	}
+	revoked := make(map[string]time.Time, len(file.Revoked))
+	for _, id := range file.Revoked {
+		revoked[id] = time.Time{}
+	}
+
+	r.mu.Lock()
+	for id, record := range keys {
+		if previous, ok := r.keys[id]; ok {
+			record.LastUsedAt = previous.LastUsedAt
+		}
+	}
+	r.keys = keys
+	r.revoked = revoked
+	r.mu.Unlock()
+	r.lastContent = data
+
+	r.log.Info("API key file loaded", "path", r.path, "keys", len(keys), "revoked", len(revoked))
+	return nil
+}
+
+// persist writes the current keys to a temporary file and renames it over
+// the key file, so readers and the watcher never see a partial file. Callers
+// must hold writeMu.
+func (r *FileApiKeyRepository) persist() error {
+	var file apiKeyFile
+	r.mu.RLock()
+	for _, record := range r.keys {
+		file.Keys = append(file.Keys, apiKeyToFileEntry(record))
+	}
+	for id := range r.revoked {
+		file.Revoked = append(file.Revoked, id)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(file.Keys, func(i, j int) bool { return file.Keys[i].ID < file.Keys[j].ID })
+	sort.Strings(file.Revoked)
+
+	data, err := r.marshal(&file)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), "."+filepath.Base(r.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write api key file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write api key file: %w", err)
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write api key file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write api key file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("failed to write api key file: %w", err)
+	}
+	r.lastContent = data
+	return nil
+}
+
+func (r *FileApiKeyRepository) isJSON() bool {
+	return strings.EqualFold(filepath.Ext(r.path), ".json")
+}
+
+func (r *FileApiKeyRepository) unmarshal(data []byte, file *apiKeyFile) error {
+	if r.isJSON() {
+		return json.Unmarshal(data, file)
+	}
+	return yaml.Unmarshal(data, file)
+}
+
+func (r *FileApiKeyRepository) marshal(file *apiKeyFile) ([]byte, error) {
+	if r.isJSON() {
+		return json.MarshalIndent(file, "", "  ")
+	}
+	return yaml.Marshal(file)
+}
+
+// apiKeyFromFileEntry rejects malformed timestamps, a mistyped expires_at
+// must not turn into a key that never expires
+func apiKeyFromFileEntry(entry apiKeyFileEntry) (*entities.ApiKey, error) {
+	record := &entities.ApiKey{
+		ID:         entry.ID,
+		Salt:       entry.Salt,
+		SecretHash: entry.SecretHash,
+		Owner:      entry.Owner,
+		Scopes:     entry.Scopes,
+		Roles:      entry.Roles,
+		Plan:       entry.Plan,
+		Disabled:   entry.Disabled,
+	}
+	for _, field := range []struct {
+		value  string
+		target *time.Time
+	}{
+		{entry.CreatedAt, &record.CreatedAt},
+		{entry.ExpiresAt, &record.ExpiresAt},
+	} {
+		if field.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, field.value)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = parsed
+	}
+	return record, nil
+}
+
+func apiKeyToFileEntry(record *entities.ApiKey) apiKeyFileEntry {
+	return apiKeyFileEntry{
+		ID:         record.ID,
+		Salt:       record.Salt,
+		SecretHash: record.SecretHash,
+		Owner:      record.Owner,
+		Scopes:     record.Scopes,
+		Roles:      record.Roles,
+		Plan:       record.Plan,
+		CreatedAt:  formatTime(record.CreatedAt),
+		ExpiresAt:  formatTime(record.ExpiresAt),
+		Disabled:   record.Disabled,
+	}
+}
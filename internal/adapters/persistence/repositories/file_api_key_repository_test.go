@@ -0,0 +1,99 @@
+package repositories_test
+
+import (
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/domain/entities"
+	"api-gateway/pkg/logger"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKeyFile(t *testing.T, path, id, secret string) {
+	t.Helper()
+	record := &entities.ApiKey{ID: id}
+	require.NoError(t, record.SetSecret(secret))
+
+	content := fmt.Sprintf("keys:\n  - id: %q\n    salt: %q\n    secret_hash: %q\n    owner: \"billing\"\n    scopes: [\"orders:read\"]\n",
+		record.ID, record.Salt, record.SecretHash)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestFileApiKeyRepository_LoadsAndReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeyFile(t, path, "k1", "one")
+
+	repo, err := repositories.NewFileApiKeyRepository(path, logger.New("test"))
+	require.NoError(t, err)
+	defer repo.(io.Closer).Close()
+	ctx := context.Background()
+
+	record, err := repo.FindKey(ctx, "k1.one")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders:read"}, record.Scopes)
+
+	writeKeyFile(t, path, "k2", "two")
+
+	assert.Eventually(t, func() bool {
+		valid, _ := repo.IsValidKey(ctx, "k2.two")
+		return valid
+	}, 2*time.Second, 20*time.Millisecond)
+
+	valid, err := repo.IsValidKey(ctx, "k1.one")
+	require.NoError(t, err)
+	assert.False(t, valid, "keys removed from the file are no longer valid")
+}
+
+func TestFileApiKeyRepository_KeepsKeysOnInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeyFile(t, path, "k1", "one")
+
+	repo, err := repositories.NewFileApiKeyRepository(path, logger.New("test"))
+	require.NoError(t, err)
+	defer repo.(io.Closer).Close()
+
+	require.NoError(t, os.WriteFile(path, []byte("keys:\n  - id: \"k2\"\n"), 0o600))
+	time.Sleep(500 * time.Millisecond)
+
+	valid, err := repo.IsValidKey(context.Background(), "k1.one")
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestFileApiKeyRepository_PersistsMutations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+
+	repo, err := repositories.NewFileApiKeyRepository(path, logger.New("test"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing"}))
+	require.NoError(t, repo.StoreKey(ctx, "k2.two", &entities.ApiKey{Owner: "reports"}))
+	require.NoError(t, repo.RevokeKey(ctx, "k2"))
+	require.NoError(t, repo.(io.Closer).Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "one")
+
+	reopened, err := repositories.NewFileApiKeyRepository(path, logger.New("test"))
+	require.NoError(t, err)
+	defer reopened.(io.Closer).Close()
+
+	valid, err := reopened.IsValidKey(ctx, "k1.one")
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	seed := &entities.ApiKey{ID: "k2", Owner: "reports"}
+	require.NoError(t, seed.SetSecret("two"))
+	seeded, err := reopened.SeedKey(ctx, seed)
+	require.NoError(t, err)
+	assert.False(t, seeded, "revocations survive a restart")
+}
@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryApiKeyRepository keeps API keys in process memory. Keys are lost on
+// restart, which makes it suitable for tests and local development.
+type MemoryApiKeyRepository struct {
+	mu      sync.RWMutex
+	keys    map[string]*entities.ApiKey
+	revoked map[string]time.Time
+	log     logger.Logger
+}
+
+func NewMemoryApiKeyRepository(log logger.Logger) ports.ApiKeyRepository {
+	return newMemoryApiKeyRepository(log)
+}
+
+func newMemoryApiKeyRepository(log logger.Logger) *MemoryApiKeyRepository {
+	return &MemoryApiKeyRepository{
+		keys:    make(map[string]*entities.ApiKey),
+		revoked: make(map[string]time.Time),
+		log:     log,
+	}
+}
+
+// HealthCheck always succeeds, there is no backing store to reach
+func (r *MemoryApiKeyRepository) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// IsValidKey checks if an API key exists, is enabled and has not expired
+func (r *MemoryApiKeyRepository) IsValidKey(ctx context.Context, key string) (bool, error) {
+	record, err := r.FindKey(ctx, key)
+	if err != nil {
+		return false, nil
+	}
+
+	if err := record.CheckUsable(time.Now()); err != nil {
+		r.log.Debug("API key not usable", "key_id", record.ID, "reason", err)
+		return false, nil
+	}
+
+	r.RecordKeyUsage(ctx, record.ID)
+	return true, nil
+}
+
+// FindKey looks a key up by its identifier and returns the record only when
+// the secret matches the stored hash
+func (r *MemoryApiKeyRepository) FindKey(ctx context.Context, key string) (*entities.ApiKey, error) {
+	id, secret := entities.ParseApiKey(key)
+
+	record, err := r.GetKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !record.VerifySecret(secret) {
+		r.log.Debug("API key secret mismatch", "key_id", id)
+		return nil, domainErrors.ErrApiKeyNotFound
+	}
+	return record, nil
+}
+
+// GetKey returns a copy of the record stored under an identifier
+func (r *MemoryApiKeyRepository) GetKey(ctx context.Context, id string) (*entities.ApiKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, ok := r.keys[id]
+	if !ok {
+		return nil, domainErrors.ErrApiKeyNotFound
+	}
+	return cloneApiKey(record), nil
+}
+
+// ListKeys returns copies of all records ordered by id
+func (r *MemoryApiKeyRepository) ListKeys(ctx context.Context) ([]*entities.ApiKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]*entities.ApiKey, 0, len(r.keys))
+	for _, record := range r.keys {
+		records = append(records, cloneApiKey(record))
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	return records, nil
+}
+
+// GetKeyMetadata retrieves metadata for an API key by its identifier
+func (r *MemoryApiKeyRepository) GetKeyMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	record, err := r.GetKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return apiKeyMetadata(record), nil
+}
+
+// apiKeyMetadata returns the same fields as the Redis hash of a record, never
+// exposing the salt or secret hash
+func apiKeyMetadata(record *entities.ApiKey) map[string]interface{} {
+	metadata := make(map[string]interface{})
+	for k, v := range apiKeyToHash(record) {
+		if k == apiKeyFieldSalt || k == apiKeyFieldSecretHash {
+			continue
+		}
+		metadata[k] = v
+	}
+	return metadata
+}
+
+// StoreKey hashes the secret of a key and stores the record under its identifier
+func (r *MemoryApiKeyRepository) StoreKey(ctx context.Context, key string, record *entities.ApiKey) error {
+	id, secret := entities.ParseApiKey(key)
+	if record.ID == "" {
+		record.ID = id
+	}
+	if record.ID != id {
+		return domainErrors.ErrApiKeyInvalidID
+	}
+	if err := record.SetSecret(secret); err != nil {
+		return err
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.keys[record.ID] = cloneApiKey(record)
+	r.mu.Unlock()
+
+	r.log.Info("API key stored", "key_id", record.ID, "owner", record.Owner)
+	return nil
+}
+
+// SeedKey stores a pre-hashed record unless its id is already stored or was revoked
+func (r *MemoryApiKeyRepository) SeedKey(ctx context.Context, record *entities.ApiKey) (bool, error) {
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	if err := record.Validate(); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[record.ID]; ok {
+		return false, nil
+	}
+	if _, ok := r.revoked[record.ID]; ok {
+		return false, nil
+	}
+	r.keys[record.ID] = cloneApiKey(record)
+
+	r.log.Info("API key seeded", "key_id", record.ID, "owner", record.Owner)
+	return true, nil
+}
+
+// UpdateKey overwrites an existing record, keeping its identifier
+func (r *MemoryApiKeyRepository) UpdateKey(ctx context.Context, record *entities.ApiKey) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[record.ID]; !ok {
+		return domainErrors.ErrApiKeyNotFound
+	}
+	r.keys[record.ID] = cloneApiKey(record)
+
+	r.log.Info("API key updated", "key_id", record.ID, "owner", record.Owner)
+	return nil
+}
+
+// RevokeKey removes an API key and remembers the id so it cannot be seeded again
+func (r *MemoryApiKeyRepository) RevokeKey(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[id]; !ok {
+		return domainErrors.ErrApiKeyNotFound
+	}
+	delete(r.keys, id)
+	r.revoked[id] = time.Now()
+
+	r.log.Info("API key revoked", "key_id", id)
+	return nil
+}
+
+// RecordKeyUsage records the last use of a key
+func (r *MemoryApiKeyRepository) RecordKeyUsage(ctx context.Context, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record, ok := r.keys[id]; ok {
+		record.LastUsedAt = time.Now()
+	}
+}
+
+// cloneApiKey copies a record so callers cannot change stored state in place
+func cloneApiKey(record *entities.ApiKey) *entities.ApiKey {
+	clone := *record
+	clone.Scopes = append([]string(nil), record.Scopes...)
+	clone.Roles = append([]string(nil), record.Roles...)
+	return &clone
+}
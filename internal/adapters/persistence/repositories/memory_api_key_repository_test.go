@@ -0,0 +1,65 @@
+package repositories_test
+
+import (
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryApiKeyRepository_Lifecycle(t *testing.T) {
+	repo := repositories.NewMemoryApiKeyRepository(logger.New("test"))
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing", Scopes: []string{"orders:read"}}))
+	require.NoError(t, repo.StoreKey(ctx, "k2.two", &entities.ApiKey{Owner: "reports", CreatedAt: time.Now().Add(-time.Hour), ExpiresAt: time.Now().Add(-time.Minute)}))
+
+	valid, err := repo.IsValidKey(ctx, "k1.one")
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = repo.IsValidKey(ctx, "k1.wrong")
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	valid, err = repo.IsValidKey(ctx, "k2.two")
+	require.NoError(t, err)
+	assert.False(t, valid, "expired keys are not valid")
+
+	record, err := repo.GetKey(ctx, "k1")
+	require.NoError(t, err)
+	assert.False(t, record.LastUsedAt.IsZero())
+
+	// Records handed out are copies
+	record.Scopes[0] = "orders:write"
+	record.Disabled = true
+	stored, err := repo.GetKey(ctx, "k1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders:read"}, stored.Scopes)
+	assert.False(t, stored.Disabled)
+
+	require.NoError(t, repo.UpdateKey(ctx, record))
+	valid, err = repo.IsValidKey(ctx, "k1.one")
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	records, err := repo.ListKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "k1", records[0].ID)
+
+	require.NoError(t, repo.RevokeKey(ctx, "k1"))
+	assert.ErrorIs(t, repo.RevokeKey(ctx, "k1"), domainErrors.ErrApiKeyNotFound)
+
+	seed := &entities.ApiKey{ID: "k1", Owner: "billing"}
+	require.NoError(t, seed.SetSecret("one"))
+	seeded, err := repo.SeedKey(ctx, seed)
+	require.NoError(t, err)
+	assert.False(t, seeded, "revoked keys must not be seeded again")
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"container/list"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// aimdBackoff is the factor AIMD cuts the limit by after a dropped request
+	aimdBackoff = 0.9
+	// gradientSmoothing is how much of the newly computed limit the gradient algorithm applies per request
+	gradientSmoothing = 0.2
+	// gradientLongWindow is roughly how many requests the long term latency average covers
+	gradientLongWindow = 100
+	// gradientMinRatio bounds how fast the gradient algorithm shrinks the limit
+	gradientMinRatio = 0.5
+)
+
+// concurrencyGate tracks the requests in flight for one key
+type concurrencyGate struct {
+	config   entities.ConcurrencyLimit
+	inFlight int
+	// limit is the current limit, fractional so adaptive algorithms can grow it in small steps
+	limit float64
+	// waiters holds a channel per queued request, closed when it is handed a slot
+	waiters *list.List
+	// longLatency is the moving average latency of the gradient algorithm in seconds
+	longLatency float64
+}
+
+// MemoryConcurrencyLimiter bounds requests in flight in process memory, each
+// instance limits only the requests it forwards itself. Waiting requests are
+// served in arrival order.
+type MemoryConcurrencyLimiter struct {
+	mu    sync.Mutex
+	gates map[string]*concurrencyGate
+}
+
+func NewMemoryConcurrencyLimiter() ports.ConcurrencyLimiter {
+	return &MemoryConcurrencyLimiter{gates: make(map[string]*concurrencyGate)}
+}
+
+func (l *MemoryConcurrencyLimiter) Acquire(ctx context.Context, key string, limit *entities.ConcurrencyLimit) (ports.ConcurrencyRelease, error) {
+	l.mu.Lock()
+	gate, ok := l.gates[key]
+	if !ok || gate.config != *limit {
+		// Requests still in flight release into the replaced gate
+		gate = &concurrencyGate{config: *limit, limit: float64(limit.MaxRequests), waiters: list.New()}
+		l.gates[key] = gate
+	}
+
+	if gate.inFlight < gate.capacity() && gate.waiters.Len() == 0 {
+		gate.inFlight++
+		l.mu.Unlock()
+		return l.releaser(gate), nil
+	}
+	if gate.waiters.Len() >= limit.MaxQueue {
+		l.mu.Unlock()
+		return nil, domainErrors.ErrBackendOverloaded
+	}
+
+	granted := make(chan struct{})
+	element := gate.waiters.PushBack(granted)
+	l.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if limit.QueueTimeout > 0 {
+		timer := time.NewTimer(limit.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-granted:
+		return l.releaser(gate), nil
+	case <-timeout:
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-granted:
+		// The slot was handed over while giving up, use it rather than leak it
+		return l.releaser(gate), nil
+	default:
+		gate.waiters.Remove(element)
+		return nil, domainErrors.ErrBackendOverloaded
+	}
+}
+
+// releaser returns the slot to the gate it was taken from exactly once
+func (l *MemoryConcurrencyLimiter) releaser(gate *concurrencyGate) ports.ConcurrencyRelease {
+	var once sync.Once
+	return func(outcome entities.ConcurrencyOutcome, latency time.Duration) {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			gate.inFlight--
+			gate.adapt(outcome, latency)
+			for gate.inFlight < gate.capacity() && gate.waiters.Len() > 0 {
+				gate.inFlight++
+				close(gate.waiters.Remove(gate.waiters.Front()).(chan struct{}))
+			}
+		})
+	}
+}
+
+// capacity is the number of requests allowed in flight
+func (g *concurrencyGate) capacity() int {
+	return int(g.limit)
+}
+
+// adapt moves an adaptive limit after a request finished
+func (g *concurrencyGate) adapt(outcome entities.ConcurrencyOutcome, latency time.Duration) {
+	if outcome == entities.ConcurrencyOutcomeIgnored || !g.config.IsAdaptive() {
+		return
+	}
+
+	switch {
+	case outcome == entities.ConcurrencyOutcomeDropped:
+		g.limit *= aimdBackoff
+	case g.config.Algorithm == entities.ConcurrencyAlgorithmAIMD:
+		if g.config.LatencyThreshold > 0 && latency > g.config.LatencyThreshold {
+			g.limit *= aimdBackoff
+		} else {
+			// One more slot per limit's worth of successful requests
+			g.limit += 1 / g.limit
+		}
+	case g.config.Algorithm == entities.ConcurrencyAlgorithmGradient:
+		sample := math.Max(latency.Seconds(), 1e-6)
+		if g.longLatency == 0 {
+			g.longLatency = sample
+		} else {
+			g.longLatency += (sample - g.longLatency) / gradientLongWindow
+		}
+		// Latency above the long term average shrinks the limit, the square root
+		// allows some queueing at the backend so the limit can grow while it is steady
+		ratio := math.Max(gradientMinRatio, math.Min(1, g.longLatency/sample))
+		target := g.limit*ratio + math.Sqrt(g.limit)
+		g.limit += (target - g.limit) * gradientSmoothing
+	}
+
+	g.limit = math.Max(float64(g.config.MinRequests), math.Min(float64(g.config.MaxRequests), g.limit))
+}
@@ -0,0 +1,151 @@
+package repositories_test
+
+import (
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acquireAll takes slots until the limiter sheds, it must not queue
+func acquireAll(t *testing.T, limiter ports.ConcurrencyLimiter, limit *entities.ConcurrencyLimit) []ports.ConcurrencyRelease {
+	t.Helper()
+	var releases []ports.ConcurrencyRelease
+	for {
+		release, err := limiter.Acquire(context.Background(), "orders", limit)
+		if err != nil {
+			require.ErrorIs(t, err, domainErrors.ErrBackendOverloaded)
+			return releases
+		}
+		releases = append(releases, release)
+	}
+}
+
+func TestMemoryConcurrencyLimiter_Fixed(t *testing.T) {
+	limiter := repositories.NewMemoryConcurrencyLimiter()
+	limit := &entities.ConcurrencyLimit{MaxRequests: 2, MaxQueue: 1, QueueTimeout: time.Second}
+	ctx := context.Background()
+
+	first, err := limiter.Acquire(ctx, "orders", limit)
+	require.NoError(t, err)
+	_, err = limiter.Acquire(ctx, "orders", limit)
+	require.NoError(t, err)
+
+	// Other keys have their own slots
+	other, err := limiter.Acquire(ctx, "users", limit)
+	require.NoError(t, err)
+	other(entities.ConcurrencyOutcomeSuccess, time.Millisecond)
+
+	queued := make(chan error, 1)
+	go func() {
+		_, err := limiter.Acquire(ctx, "orders", limit)
+		queued <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	start := time.Now()
+	_, err = limiter.Acquire(ctx, "orders", limit)
+	assert.ErrorIs(t, err, domainErrors.ErrBackendOverloaded)
+	assert.Less(t, time.Since(start), limit.QueueTimeout, "a full queue sheds without waiting")
+
+	first(entities.ConcurrencyOutcomeSuccess, time.Millisecond)
+	first(entities.ConcurrencyOutcomeSuccess, time.Millisecond)
+	select {
+	case err := <-queued:
+		assert.NoError(t, err, "a released slot goes to the waiting request")
+	case <-time.After(time.Second):
+		t.Fatal("queued request was not handed the released slot")
+	}
+
+	_, err = limiter.Acquire(ctx, "orders", &entities.ConcurrencyLimit{MaxRequests: 2})
+	require.NoError(t, err, "a changed limit starts over")
+}
+
+func TestMemoryConcurrencyLimiter_QueueTimeout(t *testing.T) {
+	limiter := repositories.NewMemoryConcurrencyLimiter()
+	limit := &entities.ConcurrencyLimit{MaxRequests: 1, MaxQueue: 5, QueueTimeout: 20 * time.Millisecond}
+
+	release, err := limiter.Acquire(context.Background(), "orders", limit)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = limiter.Acquire(context.Background(), "orders", limit)
+	assert.ErrorIs(t, err, domainErrors.ErrBackendOverloaded)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = limiter.Acquire(ctx, "orders", limit)
+	assert.ErrorIs(t, err, domainErrors.ErrBackendOverloaded, "canceled requests leave the queue")
+
+	// Requests that gave up do not hold on to slots
+	release(entities.ConcurrencyOutcomeSuccess, time.Millisecond)
+	_, err = limiter.Acquire(context.Background(), "orders", limit)
+	assert.NoError(t, err)
+}
+
+func TestMemoryConcurrencyLimiter_AIMD(t *testing.T) {
+	limiter := repositories.NewMemoryConcurrencyLimiter()
+	limit := &entities.ConcurrencyLimit{
+		Algorithm:        entities.ConcurrencyAlgorithmAIMD,
+		MaxRequests:      10,
+		MinRequests:      2,
+		LatencyThreshold: 100 * time.Millisecond,
+	}
+
+	releases := acquireAll(t, limiter, limit)
+	require.Len(t, releases, 10)
+	for _, release := range releases {
+		release(entities.ConcurrencyOutcomeDropped, time.Millisecond)
+	}
+
+	// 10 * 0.9^10 leaves 3 slots
+	releases = acquireAll(t, limiter, limit)
+	require.Len(t, releases, 3)
+	for _, release := range releases {
+		release(entities.ConcurrencyOutcomeSuccess, 500*time.Millisecond)
+	}
+	releases = acquireAll(t, limiter, limit)
+	assert.Len(t, releases, 2, "slow requests count as drops down to the minimum")
+
+	// Canceled requests tell nothing about the backend
+	for _, release := range releases {
+		release(entities.ConcurrencyOutcomeIgnored, time.Second)
+	}
+	for i := 0; i < 10; i++ {
+		for _, release := range acquireAll(t, limiter, limit) {
+			release(entities.ConcurrencyOutcomeSuccess, time.Millisecond)
+		}
+	}
+	assert.Greater(t, len(acquireAll(t, limiter, limit)), 2, "successful requests grow the limit again")
+}
+
+func TestMemoryConcurrencyLimiter_Gradient(t *testing.T) {
+	limiter := repositories.NewMemoryConcurrencyLimiter()
+	limit := &entities.ConcurrencyLimit{
+		Algorithm:   entities.ConcurrencyAlgorithmGradient,
+		MaxRequests: 20,
+		MinRequests: 1,
+	}
+
+	for i := 0; i < 5; i++ {
+		releases := acquireAll(t, limiter, limit)
+		require.Len(t, releases, 20, "steady latency keeps the limit at its maximum")
+		for _, release := range releases {
+			release(entities.ConcurrencyOutcomeSuccess, 10*time.Millisecond)
+		}
+	}
+
+	for i := 0; i < 30; i++ {
+		release, err := limiter.Acquire(context.Background(), "orders", limit)
+		require.NoError(t, err)
+		release(entities.ConcurrencyOutcomeSuccess, 100*time.Millisecond)
+	}
+	assert.Less(t, len(acquireAll(t, limiter, limit)), 10, "rising latency shrinks the limit")
+}
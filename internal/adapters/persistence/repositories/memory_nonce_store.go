@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryNonceStore remembers nonces in process memory. Each instance only
+// knows the nonces it has seen itself.
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	// nextPurge is when expired nonces are dropped next
+	nextPurge time.Time
+}
+
+func NewMemoryNonceStore() ports.NonceStore {
+	return &MemoryNonceStore{nonces: make(map[string]time.Time)}
+}
+
+func (s *MemoryNonceStore) Remember(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.After(s.nextPurge) {
+		for seen, expiresAt := range s.nonces {
+			if now.After(expiresAt) {
+				delete(s.nonces, seen)
+			}
+		}
+		s.nextPurge = now.Add(ttl)
+	}
+
+	if expiresAt, ok := s.nonces[nonce]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	s.nonces[nonce] = now.Add(ttl)
+	return true, nil
+}
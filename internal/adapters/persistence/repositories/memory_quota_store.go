@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	"context"
+	"sync"
+	"time"
+)
+
+// quotaPurgeInterval is how often counters of windows that have reset are dropped
+const quotaPurgeInterval = time.Hour
+
+type quotaCounter struct {
+	used      int64
+	expiresAt time.Time
+}
+
+// MemoryQuotaStore counts quota usage in process memory. Each instance counts
+// only the requests it has served itself and counters are lost on restart.
+type MemoryQuotaStore struct {
+	mu       sync.Mutex
+	counters map[string]*quotaCounter
+	// nextPurge is when expired counters are dropped next
+	nextPurge time.Time
+}
+
+func NewMemoryQuotaStore() ports.QuotaStore {
+	return &MemoryQuotaStore{counters: make(map[string]*quotaCounter)}
+}
+
+func (s *MemoryQuotaStore) Consume(ctx context.Context, keyID string, windows []entities.QuotaWindow) ([]entities.QuotaUsage, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.After(s.nextPurge) {
+		for key, counter := range s.counters {
+			if now.After(counter.expiresAt) {
+				delete(s.counters, key)
+			}
+		}
+		s.nextPurge = now.Add(quotaPurgeInterval)
+	}
+
+	usage := s.usage(keyID, windows)
+	for _, window := range usage {
+		if window.Exhausted() {
+			return usage, false, nil
+		}
+	}
+
+	for i, window := range windows {
+		key := quotaKey(keyID, window)
+		counter, ok := s.counters[key]
+		if !ok {
+			counter = &quotaCounter{expiresAt: window.ResetAt.Add(quotaRetention)}
+			s.counters[key] = counter
+		}
+		counter.used++
+		usage[i].Used = counter.used
+	}
+	return usage, true, nil
+}
+
+func (s *MemoryQuotaStore) Usage(ctx context.Context, keyID string, windows []entities.QuotaWindow) ([]entities.QuotaUsage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.usage(keyID, windows), nil
+}
+
+func (s *MemoryQuotaStore) usage(keyID string, windows []entities.QuotaWindow) []entities.QuotaUsage {
+	usage := make([]entities.QuotaUsage, len(windows))
+	for i, window := range windows {
+		usage[i].QuotaWindow = window
+		if counter, ok := s.counters[quotaKey(keyID, window)]; ok {
+			usage[i].Used = counter.used
+		}
+	}
+	return usage
+}
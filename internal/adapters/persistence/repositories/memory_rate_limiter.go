@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimitPurgeInterval is how often buckets that have refilled are dropped
+const rateLimitPurgeInterval = time.Minute
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	rate    float64
+	burst   float64
+}
+
+// MemoryRateLimiter is a token bucket limiter in process memory. Each instance
+// counts only the requests it has served itself.
+type MemoryRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	// nextPurge is when full buckets are dropped next
+	nextPurge time.Time
+}
+
+func NewMemoryRateLimiter() ports.RateLimiter {
+	return &MemoryRateLimiter{buckets: make(map[string]*tokenBucket)}
+}
+
+func (l *MemoryRateLimiter) Allow(ctx context.Context, key string, limit *entities.RateLimit) (*entities.RateLimitDecision, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.After(l.nextPurge) {
+		// A full bucket behaves exactly like a missing one
+		for bucketKey, bucket := range l.buckets {
+			if bucket.refill(now) >= bucket.burst {
+				delete(l.buckets, bucketKey)
+			}
+		}
+		l.nextPurge = now.Add(rateLimitPurgeInterval)
+	}
+
+	burst := float64(limit.Burst)
+	bucket, ok := l.buckets[key]
+	if !ok || bucket.rate != limit.Rate || bucket.burst != burst {
+		bucket = &tokenBucket{tokens: burst, updated: now, rate: limit.Rate, burst: burst}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = bucket.refill(now)
+	bucket.updated = now
+
+	decision := &entities.RateLimitDecision{Limit: limit.Burst}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - bucket.tokens) / bucket.rate)
+	}
+	decision.Remaining = int(math.Floor(bucket.tokens))
+	decision.ResetAfter = secondsToDuration((bucket.burst - bucket.tokens) / bucket.rate)
+	return decision, nil
+}
+
+// refill returns the tokens in the bucket at now
+func (b *tokenBucket) refill(now time.Time) float64 {
+	return math.Min(b.burst, b.tokens+now.Sub(b.updated).Seconds()*b.rate)
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
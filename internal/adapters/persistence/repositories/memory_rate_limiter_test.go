@@ -0,0 +1,72 @@
+package repositories_test
+
+import (
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/domain/entities"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRateLimiter_Burst(t *testing.T) {
+	limiter := repositories.NewMemoryRateLimiter()
+	limit := &entities.RateLimit{Name: "orders", Rate: 1, Burst: 3}
+	ctx := context.Background()
+
+	for want := 2; want >= 0; want-- {
+		decision, err := limiter.Allow(ctx, "orders|key:k1", limit)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, 3, decision.Limit)
+		assert.Equal(t, want, decision.Remaining)
+	}
+
+	decision, err := limiter.Allow(ctx, "orders|key:k1", limit)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+	assert.InDelta(t, time.Second.Seconds(), decision.RetryAfter.Seconds(), 0.05)
+	assert.InDelta(t, (3 * time.Second).Seconds(), decision.ResetAfter.Seconds(), 0.05)
+
+	// Other keys have their own bucket
+	decision, err = limiter.Allow(ctx, "orders|key:k2", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestMemoryRateLimiter_Refill(t *testing.T) {
+	limiter := repositories.NewMemoryRateLimiter()
+	limit := &entities.RateLimit{Name: "orders", Rate: 50, Burst: 1}
+	ctx := context.Background()
+
+	decision, err := limiter.Allow(ctx, "orders|ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+
+	decision, err = limiter.Allow(ctx, "orders|ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+
+	time.Sleep(30 * time.Millisecond)
+
+	decision, err = limiter.Allow(ctx, "orders|ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestMemoryRateLimiter_ChangedLimitStartsFull(t *testing.T) {
+	limiter := repositories.NewMemoryRateLimiter()
+	ctx := context.Background()
+
+	decision, err := limiter.Allow(ctx, "orders|route", &entities.RateLimit{Name: "orders", Rate: 1, Burst: 1})
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+
+	decision, err = limiter.Allow(ctx, "orders|route", &entities.RateLimit{Name: "orders", Rate: 1, Burst: 5})
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 4, decision.Remaining)
+}
@@ -90,13 +90,17 @@ func (repo *MemoryRouteRepo) FindByPathAndMethod(ctx context.Context, path, meth
 			)
 
 			// Pass backend ID to match
-			if route.Match(path, method, backendID) && route.IsEnabled() {
+			params, ok := route.MatchParams(path, method, backendID)
+			if ok && route.IsEnabled() {
 				repo.log.Info("Route matched",
 					"route_id", route.ID,
 					"route_path", route.Path,
 					"backend_id", backendID,
 				)
-				return route, nil
+				// Callers get a copy so per request values never leak into the stored route
+				matched := *route
+				matched.Params = params
+				return &matched, nil
 			}
 		}
 	}
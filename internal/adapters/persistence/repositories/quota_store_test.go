@@ -0,0 +1,93 @@
+package repositories_test
+
+import (
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var quotaNow = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+func newRedisQuotaStore(t *testing.T) (ports.QuotaStore, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	server.SetTime(quotaNow)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	return repositories.NewRedisQuotaStore(client), server
+}
+
+func TestQuotaStore_Consume(t *testing.T) {
+	stores := map[string]func(t *testing.T) ports.QuotaStore{
+		"memory": func(t *testing.T) ports.QuotaStore { return repositories.NewMemoryQuotaStore() },
+		"redis": func(t *testing.T) ports.QuotaStore {
+			store, _ := newRedisQuotaStore(t)
+			return store
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			windows := (&entities.QuotaPlan{Name: "pro", Daily: 2, Monthly: 3}).Windows(quotaNow)
+			ctx := context.Background()
+
+			for want := int64(1); want <= 2; want++ {
+				usage, allowed, err := store.Consume(ctx, "k1", windows)
+				require.NoError(t, err)
+				assert.True(t, allowed)
+				require.Len(t, usage, 2)
+				assert.Equal(t, want, usage[0].Used)
+				assert.Equal(t, want, usage[1].Used)
+			}
+
+			// The exhausted daily window leaves the monthly one untouched
+			usage, allowed, err := store.Consume(ctx, "k1", windows)
+			require.NoError(t, err)
+			assert.False(t, allowed)
+			assert.Equal(t, int64(2), usage[0].Used)
+			assert.Equal(t, int64(0), usage[0].Remaining())
+			assert.Equal(t, int64(2), usage[1].Used)
+			assert.Equal(t, int64(1), usage[1].Remaining())
+
+			// The next day only the monthly quota is left
+			tomorrow := (&entities.QuotaPlan{Name: "pro", Daily: 2, Monthly: 3}).Windows(quotaNow.Add(24 * time.Hour))
+			_, allowed, err = store.Consume(ctx, "k1", tomorrow)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+			_, allowed, err = store.Consume(ctx, "k1", tomorrow)
+			require.NoError(t, err)
+			assert.False(t, allowed)
+
+			usage, err = store.Usage(ctx, "k1", tomorrow)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), usage[0].Used)
+			assert.Equal(t, int64(3), usage[1].Used)
+
+			// Other keys are counted on their own
+			usage, err = store.Usage(ctx, "k2", windows)
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), usage[0].Used)
+		})
+	}
+}
+
+func TestRedisQuotaStore_ExpiresAfterReset(t *testing.T) {
+	store, server := newRedisQuotaStore(t)
+	windows := (&entities.QuotaPlan{Name: "free", Daily: 10}).Windows(quotaNow)
+
+	_, allowed, err := store.Consume(context.Background(), "k1", windows)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	assert.True(t, server.Exists("quota:k1:daily:2026-10-17"))
+	assert.Equal(t, 13*time.Hour, server.TTL("quota:k1:daily:2026-10-17"))
+}
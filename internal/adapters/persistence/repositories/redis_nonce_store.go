@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const nonceKeyPrefix = "nonce:"
+
+// RedisNonceStore shares seen nonces between gateway instances, a request
+// replayed against another instance is rejected as well
+type RedisNonceStore struct {
+	client *redis.Client
+}
+
+func NewRedisNonceStore(client *redis.Client) ports.NonceStore {
+	return &RedisNonceStore{client: client}
+}
+
+func (s *RedisNonceStore) Remember(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, nonceKeyPrefix+nonce, 1, ttl).Result()
+}
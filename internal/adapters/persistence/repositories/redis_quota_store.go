@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const quotaKeyPrefix = "quota:"
+
+// quotaRetention keeps counters a while after their window reset, so that
+// instances with a slightly late clock still find them
+const quotaRetention = time.Hour
+
+// quotaConsumeScript counts a request in every window of a plan, or in none of
+// them when one is exhausted already.
+//
+//	KEYS     one counter per window
+//	ARGV     the limit of every window, followed by the unix time every counter expires at
+//
+// Returns whether the request was counted followed by the usage of every window.
+var quotaConsumeScript = redis.NewScript(`
+local used = {}
+local allowed = 1
+for i, key in ipairs(KEYS) do
+  used[i] = tonumber(redis.call('GET', key) or '0')
+  if used[i] >= tonumber(ARGV[i]) then
+    allowed = 0
+  end
+end
+
+if allowed == 1 then
+  for i, key in ipairs(KEYS) do
+    used[i] = redis.call('INCR', key)
+    redis.call('EXPIREAT', key, ARGV[#KEYS + i])
+  end
+end
+
+table.insert(used, 1, allowed)
+return used
+`)
+
+// RedisQuotaStore shares quota usage between gateway instances
+type RedisQuotaStore struct {
+	client *redis.Client
+}
+
+func NewRedisQuotaStore(client *redis.Client) ports.QuotaStore {
+	return &RedisQuotaStore{client: client}
+}
+
+func (s *RedisQuotaStore) Consume(ctx context.Context, keyID string, windows []entities.QuotaWindow) ([]entities.QuotaUsage, bool, error) {
+	if len(windows) == 0 {
+		return nil, true, nil
+	}
+
+	keys := make([]string, len(windows))
+	args := make([]interface{}, 2*len(windows))
+	for i, window := range windows {
+		keys[i] = quotaKeyPrefix + quotaKey(keyID, window)
+		args[i] = window.Limit
+		args[len(windows)+i] = window.ResetAt.Add(quotaRetention).Unix()
+	}
+
+	result, err := quotaConsumeScript.Run(ctx, s.client, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(result) != len(windows)+1 {
+		return nil, false, fmt.Errorf("unexpected quota script result %v", result)
+	}
+
+	usage := make([]entities.QuotaUsage, len(windows))
+	for i, window := range windows {
+		usage[i] = entities.QuotaUsage{QuotaWindow: window, Used: result[i+1]}
+	}
+	return usage, result[0] == 1, nil
+}
+
+func (s *RedisQuotaStore) Usage(ctx context.Context, keyID string, windows []entities.QuotaWindow) ([]entities.QuotaUsage, error) {
+	if len(windows) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(windows))
+	for i, window := range windows {
+		keys[i] = quotaKeyPrefix + quotaKey(keyID, window)
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]entities.QuotaUsage, len(windows))
+	for i, window := range windows {
+		usage[i].QuotaWindow = window
+		value, ok := values[i].(string)
+		if !ok {
+			continue
+		}
+		used, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quota counter %s: %w", keys[i], err)
+		}
+		usage[i].Used = used
+	}
+	return usage, nil
+}
+
+// quotaKey names the counter of a key in a window, e.g. k7f3a9:daily:2026-10-17
+func quotaKey(keyID string, window entities.QuotaWindow) string {
+	return keyID + ":" + string(window.Period) + ":" + window.ID
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	"api-gateway/pkg/logger"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	rateLimitKeyPrefix = "ratelimit:"
+	// rateLimitFallbackPeriod is how long the local limiter is used after Redis failed
+	rateLimitFallbackPeriod = 5 * time.Second
+)
+
+// gcraScript implements the generic cell rate algorithm. The key holds the
+// theoretical arrival time (TAT) of the next request in microseconds; a request
+// is allowed when it does not arrive earlier than TAT minus the burst
+// tolerance. Redis' clock is used so that replicas agree on the time.
+//
+//	KEYS[1]  bucket
+//	ARGV[1]  emission interval, microseconds between requests at the rate
+//	ARGV[2]  burst
+//
+// Returns allowed (0/1), remaining, retry after and reset after in microseconds.
+var gcraScript = redis.NewScript(`
+local emission = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+  tat = now
+end
+
+local tolerance = emission * burst
+local new_tat = tat + emission
+local allow_at = new_tat - tolerance
+if now < allow_at then
+  return {0, 0, math.ceil(allow_at - now), math.ceil(tat - now)}
+end
+
+redis.call('SET', KEYS[1], string.format('%.0f', new_tat), 'PX', math.ceil((new_tat - now) / 1000))
+return {1, math.floor((now - allow_at) / emission), 0, math.ceil(new_tat - now)}
+`)
+
+// RedisRateLimiter shares rate limits between gateway instances. While Redis
+// is unreachable requests are counted by a local limiter instead, so each
+// instance enforces the limit on its own rather than not at all.
+type RedisRateLimiter struct {
+	client   *redis.Client
+	fallback ports.RateLimiter
+	log      logger.Logger
+
+	mu sync.Mutex
+	// fallbackUntil is when Redis is tried again after a failure
+	fallbackUntil time.Time
+}
+
+func NewRedisRateLimiter(client *redis.Client, fallback ports.RateLimiter, log logger.Logger) ports.RateLimiter {
+	return &RedisRateLimiter{
+		client:   client,
+		fallback: fallback,
+		log:      log.With("component", "redis_rate_limiter"),
+	}
+}
+
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, limit *entities.RateLimit) (*entities.RateLimitDecision, error) {
+	if l.usingFallback() {
+		return l.fallback.Allow(ctx, key, limit)
+	}
+
+	decision, err := l.allow(ctx, key, limit)
+	if err != nil {
+		l.mu.Lock()
+		l.fallbackUntil = time.Now().Add(rateLimitFallbackPeriod)
+		l.mu.Unlock()
+		l.log.Warn("Redis rate limiter unavailable, counting requests locally",
+			"retry_in", rateLimitFallbackPeriod,
+			"error", err)
+		return l.fallback.Allow(ctx, key, limit)
+	}
+	return decision, nil
+}
+
+func (l *RedisRateLimiter) allow(ctx context.Context, key string, limit *entities.RateLimit) (*entities.RateLimitDecision, error) {
+	emission := float64(time.Second/time.Microsecond) / limit.Rate
+	result, err := gcraScript.Run(ctx, l.client, []string{rateLimitKeyPrefix + key}, emission, limit.Burst).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != 4 {
+		return nil, fmt.Errorf("unexpected rate limit script result %v", result)
+	}
+
+	return &entities.RateLimitDecision{
+		Allowed:    result[0] == 1,
+		Limit:      limit.Burst,
+		Remaining:  int(result[1]),
+		RetryAfter: time.Duration(result[2]) * time.Microsecond,
+		ResetAfter: time.Duration(result[3]) * time.Microsecond,
+	}, nil
+}
+
+func (l *RedisRateLimiter) usingFallback() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return time.Now().Before(l.fallbackUntil)
+}
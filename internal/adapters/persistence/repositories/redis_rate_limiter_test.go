@@ -0,0 +1,112 @@
+package repositories_test
+
+import (
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	"api-gateway/pkg/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedisRateLimiter(t *testing.T) (ports.RateLimiter, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	server.SetTime(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	return repositories.NewRedisRateLimiter(client, repositories.NewMemoryRateLimiter(), logger.New("test")), server
+}
+
+func TestRedisRateLimiter_Burst(t *testing.T) {
+	limiter, server := newRedisRateLimiter(t)
+	limit := &entities.RateLimit{Name: "orders", Rate: 1, Burst: 3}
+	ctx := context.Background()
+
+	for want := 2; want >= 0; want-- {
+		decision, err := limiter.Allow(ctx, "orders|key:k1", limit)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, 3, decision.Limit)
+		assert.Equal(t, want, decision.Remaining)
+	}
+
+	decision, err := limiter.Allow(ctx, "orders|key:k1", limit)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+	assert.Equal(t, time.Second, decision.RetryAfter)
+	assert.Equal(t, 3*time.Second, decision.ResetAfter)
+	assert.True(t, server.Exists("ratelimit:orders|key:k1"))
+
+	// Other keys have their own bucket
+	decision, err = limiter.Allow(ctx, "orders|key:k2", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestRedisRateLimiter_Refill(t *testing.T) {
+	limiter, server := newRedisRateLimiter(t)
+	limit := &entities.RateLimit{Name: "orders", Rate: 2, Burst: 1}
+	ctx := context.Background()
+
+	decision, err := limiter.Allow(ctx, "orders|ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+
+	decision, err = limiter.Allow(ctx, "orders|ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	assert.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+
+	server.SetTime(time.Date(2026, 1, 1, 12, 0, 0, int(500*time.Millisecond), time.UTC))
+
+	decision, err = limiter.Allow(ctx, "orders|ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestRedisRateLimiter_SharedBetweenInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	limit := &entities.RateLimit{Name: "orders", Rate: 1, Burst: 2}
+	ctx := context.Background()
+
+	var limiters []ports.RateLimiter
+	for i := 0; i < 2; i++ {
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+		limiters = append(limiters, repositories.NewRedisRateLimiter(client, repositories.NewMemoryRateLimiter(), logger.New("test")))
+	}
+
+	allowed := 0
+	for i := 0; i < 4; i++ {
+		decision, err := limiters[i%2].Allow(ctx, "orders|route", limit)
+		require.NoError(t, err)
+		if decision.Allowed {
+			allowed++
+		}
+	}
+	assert.Equal(t, 2, allowed)
+}
+
+func TestRedisRateLimiter_FallsBackWhenRedisIsDown(t *testing.T) {
+	limiter, server := newRedisRateLimiter(t)
+	limit := &entities.RateLimit{Name: "orders", Rate: 1, Burst: 1}
+	ctx := context.Background()
+
+	server.Close()
+
+	decision, err := limiter.Allow(ctx, "orders|key:k1", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	decision, err = limiter.Allow(ctx, "orders|key:k1", limit)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+}
@@ -2,14 +2,76 @@ package repositories
 
 import (
 	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
 	"api-gateway/pkg/logger"
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// API key records are stored as Redis hashes under apikey:<id> with these fields.
+// The secret part of a key is only kept as a salted hash.
+const (
+	apiKeyFieldID         = "id"
+	apiKeyFieldSalt       = "salt"
+	apiKeyFieldSecretHash = "secret_hash"
+	apiKeyFieldOwner      = "owner"
+	apiKeyFieldScopes     = "scopes"
+	apiKeyFieldRoles      = "roles"
+	apiKeyFieldPlan       = "plan"
+	apiKeyFieldCreatedAt  = "created_at"
+	apiKeyFieldExpiresAt  = "expires_at"
+	apiKeyFieldLastUsedAt = "last_used_at"
+	apiKeyFieldDisabled   = "disabled"
+)
+
+// apiKeyTombstonePrefix marks ids that were revoked so seeding never brings them back
+const apiKeyTombstonePrefix = "apikey_revoked:"
+
+// apiKeyChangesChannel is the pub/sub channel carrying the id of every changed
+// key, so gateway instances can drop cached copies
+const apiKeyChangesChannel = "apikey_changes"
+
+// legacyApiKeyOwner is assigned to migrated plaintext keys that had no owner
+const legacyApiKeyOwner = "legacy"
+
+// hsetIfExistsScript sets fields of a hash only when it still exists, so a
+// write racing a revocation cannot recreate the key.
+//
+//	KEYS[1]  the hash
+//	ARGV     field and value pairs
+//
+// Returns 1 when the fields were set, 0 when the hash does not exist.
+var hsetIfExistsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return 0
+end
+redis.call('HSET', KEYS[1], unpack(ARGV))
+return 1
+`)
+
+// revokeScript deletes a key and leaves a tombstone, only if the key existed
+// so that revoking a mistyped id does not block it from being seeded.
+//
+//	KEYS[1]  the hash
+//	KEYS[2]  the tombstone
+//	ARGV[1]  the revocation time
+//
+// Returns 1 when the key was revoked, 0 when it does not exist.
+var revokeScript = redis.NewScript(`
+if redis.call('DEL', KEYS[1]) == 0 then
+  return 0
+end
+redis.call('SET', KEYS[2], ARGV[1])
+return 1
+`)
+
 type RedisApiKeyRepository struct {
 	client *redis.Client
 	log    logger.Logger
@@ -22,7 +84,8 @@ func NewRedisApiKeyRepository(client *redis.Client, log logger.Logger) ports.Api
 	}
 }
 
-// HealthCheck Health check implementation
+// HealthCheck Health check implementation. It must stay free of side effects
+// because readiness probes call it continuously.
 func (r *RedisApiKeyRepository) HealthCheck(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
@@ -33,85 +96,427 @@ func (r *RedisApiKeyRepository) HealthCheck(ctx context.Context) error {
 		return fmt.Errorf("redis health check failed: %w", err)
 	}
 
-	r.client.Set(ctx, "key-123", true, 0)
-	r.client.Set(ctx, "key-1234", false, 0)
-
 	return nil
 }
 
-// IsValidKey checks if an API key exists and is valid in Redis
+// IsValidKey checks if an API key exists in Redis, is enabled and has not expired
 func (r *RedisApiKeyRepository) IsValidKey(ctx context.Context, key string) (bool, error) {
-	// Check if key exists in Redis
-	exists, err := r.client.Exists(ctx, key).Result()
+	record, err := r.FindKey(ctx, key)
+	if errors.Is(err, domainErrors.ErrApiKeyNotFound) {
+		return false, nil
+	}
 	if err != nil {
-		r.log.Error("Failed to check API key in Redis", "error", err)
 		return false, err
 	}
 
-	if exists == 0 {
+	if err := record.CheckUsable(time.Now()); err != nil {
+		r.log.Debug("API key not usable", "key_id", record.ID, "reason", err)
 		return false, nil
 	}
 
-	isValidKey := r.client.Get(ctx, key).Val()
+	r.RecordKeyUsage(ctx, record.ID)
+	return true, nil
+}
+
+// FindKey looks a key up by its identifier and returns the record only when
+// the secret matches the stored hash
+func (r *RedisApiKeyRepository) FindKey(ctx context.Context, key string) (*entities.ApiKey, error) {
+	id, secret := entities.ParseApiKey(key)
 
-	return isValidKey == "1", nil
+	data, err := r.client.HGetAll(ctx, apiKeyRedisKey(id)).Result()
+	if err != nil {
+		r.log.Error("Failed to load API key from Redis", "error", err)
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, domainErrors.ErrApiKeyNotFound
+	}
+
+	record := apiKeyFromHash(data)
+	if !record.VerifySecret(secret) {
+		r.log.Debug("API key secret mismatch", "key_id", id)
+		return nil, domainErrors.ErrApiKeyNotFound
+	}
+
+	return record, nil
 }
 
-// GetKeyMetadata retrieves metadata for an API key
-func (r *RedisApiKeyRepository) GetKeyMetadata(ctx context.Context, key string) (map[string]interface{}, error) {
-	redisKey := fmt.Sprintf("apikey:%s", key)
+// GetKey loads the record stored under an identifier
+func (r *RedisApiKeyRepository) GetKey(ctx context.Context, id string) (*entities.ApiKey, error) {
+	data, err := r.client.HGetAll(ctx, apiKeyRedisKey(id)).Result()
+	if err != nil {
+		r.log.Error("Failed to load API key from Redis", "key_id", id, "error", err)
+		return nil, err
+	}
+
+	if len(data) == 0 || data[apiKeyFieldSalt] == "" {
+		return nil, domainErrors.ErrApiKeyNotFound
+	}
 
-	// Get all fields from hash
-	data, err := r.client.HGetAll(ctx, redisKey).Result()
+	return apiKeyFromHash(data), nil
+}
+
+// ListKeys returns every salted key record
+func (r *RedisApiKeyRepository) ListKeys(ctx context.Context) ([]*entities.ApiKey, error) {
+	var records []*entities.ApiKey
+	iter := r.client.ScanType(ctx, 0, apiKeyRedisKey("*"), 100, "hash").Iterator()
+	for iter.Next(ctx) {
+		data, err := r.client.HGetAll(ctx, iter.Val()).Result()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 || data[apiKeyFieldSalt] == "" {
+			continue
+		}
+		records = append(records, apiKeyFromHash(data))
+	}
+	if err := iter.Err(); err != nil {
+		r.log.Error("Failed to list API keys from Redis", "error", err)
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// GetKeyMetadata retrieves metadata for an API key by its identifier
+func (r *RedisApiKeyRepository) GetKeyMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	data, err := r.client.HGetAll(ctx, apiKeyRedisKey(id)).Result()
 	if err != nil {
 		return nil, err
 	}
 
 	if len(data) == 0 {
-		return nil, fmt.Errorf("API key not found")
+		return nil, domainErrors.ErrApiKeyNotFound
 	}
 
-	// Convert to map[string]interface{}
+	// Convert to map[string]interface{}, never exposing the salt or secret hash
 	metadata := make(map[string]interface{})
 	for k, v := range data {
+		if k == apiKeyFieldSalt || k == apiKeyFieldSecretHash {
+			continue
+		}
 		metadata[k] = v
 	}
 
 	return metadata, nil
 }
 
-// StoreKey stores an API key in Redis with metadata
-func (r *RedisApiKeyRepository) StoreKey(ctx context.Context, key string, metadata map[string]interface{}) error {
-	redisKey := fmt.Sprintf("apikey:%s", key)
-
-	// Convert metadata to map[string]interface{} for HMSET
-	data := make(map[string]interface{})
-	for k, v := range metadata {
-		data[k] = v
+// StoreKey hashes the secret of a key and stores the record under its identifier
+func (r *RedisApiKeyRepository) StoreKey(ctx context.Context, key string, record *entities.ApiKey) error {
+	id, secret := entities.ParseApiKey(key)
+	if record.ID == "" {
+		record.ID = id
+	}
+	if record.ID != id {
+		return domainErrors.ErrApiKeyInvalidID
+	}
+	if err := record.SetSecret(secret); err != nil {
+		return fmt.Errorf("failed to hash API key secret: %w", err)
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	if err := record.Validate(); err != nil {
+		return err
 	}
 
 	// Store as hash
-	if err := r.client.HSet(ctx, redisKey, data).Err(); err != nil {
+	if err := r.client.HSet(ctx, apiKeyRedisKey(record.ID), apiKeyToHash(record)).Err(); err != nil {
 		return err
 	}
 
-	r.log.Info("API key stored", "key", key)
+	r.log.Info("API key stored", "key_id", record.ID, "owner", record.Owner)
+	r.publishChange(ctx, record.ID)
 	return nil
 }
 
-// RevokeKey removes an API key from Redis
-func (r *RedisApiKeyRepository) RevokeKey(ctx context.Context, key string) error {
-	redisKey := fmt.Sprintf("apikey:%s", key)
+// UpdateKey overwrites an existing record, keeping its identifier
+func (r *RedisApiKeyRepository) UpdateKey(ctx context.Context, record *entities.ApiKey) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	updated, err := r.hsetIfExists(ctx, apiKeyRedisKey(record.ID), apiKeyToHash(record))
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return domainErrors.ErrApiKeyNotFound
+	}
+
+	r.log.Info("API key updated", "key_id", record.ID, "owner", record.Owner)
+	r.publishChange(ctx, record.ID)
+	return nil
+}
+
+// SeedKey stores a pre-hashed record unless its id is already stored or was
+// revoked, so bootstrapping never overrides changes made since
+func (r *RedisApiKeyRepository) SeedKey(ctx context.Context, record *entities.ApiKey) (bool, error) {
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	if err := record.Validate(); err != nil {
+		return false, err
+	}
+
+	existing, err := r.client.Exists(ctx, apiKeyRedisKey(record.ID), apiKeyTombstonePrefix+record.ID).Result()
+	if err != nil {
+		return false, err
+	}
+	if existing > 0 {
+		r.log.Debug("Skipping API key seed, id already stored or revoked", "key_id", record.ID)
+		return false, nil
+	}
 
-	if err := r.client.Del(ctx, redisKey).Err(); err != nil {
+	if err := r.client.HSet(ctx, apiKeyRedisKey(record.ID), apiKeyToHash(record)).Err(); err != nil {
+		return false, err
+	}
+
+	r.log.Info("API key seeded", "key_id", record.ID, "owner", record.Owner)
+	r.publishChange(ctx, record.ID)
+	return true, nil
+}
+
+// RevokeKey removes an API key from Redis by its identifier and leaves a
+// tombstone so the id cannot be seeded again
+func (r *RedisApiKeyRepository) RevokeKey(ctx context.Context, id string) error {
+	revoked, err := revokeScript.Run(ctx, r.client, []string{apiKeyRedisKey(id), apiKeyTombstonePrefix + id}, formatTime(time.Now())).Int()
+	if err != nil {
 		return err
 	}
+	if revoked == 0 {
+		return domainErrors.ErrApiKeyNotFound
+	}
 
-	r.log.Info("API key revoked", "key", key)
+	r.log.Info("API key revoked", "key_id", id)
+	r.publishChange(ctx, id)
 	return nil
 }
 
+// MigrateLegacyKeys rewrites keys stored under their plaintext value
+// (apikey:<key> without a salt) as salted records under their identifier and
+// deletes the plaintext entries. It returns the number of migrated keys.
+func (r *RedisApiKeyRepository) MigrateLegacyKeys(ctx context.Context) (int, error) {
+	migrated := 0
+	iter := r.client.ScanType(ctx, 0, apiKeyRedisKey("*"), 100, "hash").Iterator()
+	for iter.Next(ctx) {
+		redisKey := iter.Val()
+		data, err := r.client.HGetAll(ctx, redisKey).Result()
+		if err != nil {
+			return migrated, err
+		}
+		if len(data) == 0 || data[apiKeyFieldSalt] != "" {
+			continue
+		}
+		if isPartialApiKeyRecord(data) {
+			r.log.Warn("Skipping API key hash without a salt, it is no plaintext key", "redis_key", redisKey)
+			continue
+		}
+
+		ok, err := r.migrateLegacyKey(ctx, redisKey, data)
+		if err != nil {
+			return migrated, err
+		}
+		if ok {
+			migrated++
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return migrated, err
+	}
+
+	if migrated > 0 {
+		r.log.Info("Migrated plaintext API keys", "count", migrated)
+	}
+	return migrated, nil
+}
+
+func (r *RedisApiKeyRepository) migrateLegacyKey(ctx context.Context, redisKey string, data map[string]string) (bool, error) {
+	key := strings.TrimPrefix(redisKey, apiKeyRedisKey(""))
+	id, secret := entities.ParseApiKey(key)
+
+	revoked, err := r.client.Exists(ctx, apiKeyTombstonePrefix+key, apiKeyTombstonePrefix+id).Result()
+	if err != nil {
+		return false, err
+	}
+	if revoked > 0 {
+		r.log.Warn("Skipping plaintext API key, it was revoked", "key_id", id)
+		return false, nil
+	}
+
+	exists, err := r.client.Exists(ctx, apiKeyRedisKey(id)).Result()
+	if err != nil {
+		return false, err
+	}
+	if exists > 0 {
+		r.log.Warn("Skipping plaintext API key, identifier already in use", "key_id", id)
+		return false, nil
+	}
+
+	record := apiKeyFromHash(data)
+	record.ID = id
+	if record.Owner == "" {
+		record.Owner = legacyApiKeyOwner
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	if err := record.SetSecret(secret); err != nil {
+		return false, err
+	}
+	if err := record.Validate(); err != nil {
+		r.log.Warn("Skipping invalid plaintext API key", "key_id", id, "error", err)
+		return false, nil
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, apiKeyRedisKey(id), apiKeyToHash(record))
+	pipe.Del(ctx, redisKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	r.log.Info("Migrated plaintext API key", "key_id", id, "owner", record.Owner)
+	r.publishChange(ctx, id)
+	return true, nil
+}
+
 // Close closes the Redis connection
 func (r *RedisApiKeyRepository) Close() error {
 	return r.client.Close()
 }
+
+// RecordKeyUsage records the last use of a key; failures only cost accuracy of the timestamp.
+// Keys revoked since the request was authenticated are left deleted.
+func (r *RedisApiKeyRepository) RecordKeyUsage(ctx context.Context, id string) {
+	fields := map[string]interface{}{apiKeyFieldLastUsedAt: formatTime(time.Now())}
+	if _, err := r.hsetIfExists(ctx, apiKeyRedisKey(id), fields); err != nil {
+		r.log.Warn("Failed to record API key usage", "error", err)
+	}
+}
+
+// hsetIfExists sets fields of a hash unless it was deleted, reporting whether it existed
+func (r *RedisApiKeyRepository) hsetIfExists(ctx context.Context, redisKey string, fields map[string]interface{}) (bool, error) {
+	args := make([]interface{}, 0, 2*len(fields))
+	for field, value := range fields {
+		args = append(args, field, value)
+	}
+	updated, err := hsetIfExistsScript.Run(ctx, r.client, []string{redisKey}, args...).Int()
+	if err != nil {
+		return false, err
+	}
+	return updated == 1, nil
+}
+
+// WatchKeyChanges subscribes to key changes published by any gateway instance
+// or CLI run. Every (re)subscription is reported as an empty id because
+// messages published while disconnected are lost.
+func (r *RedisApiKeyRepository) WatchKeyChanges(ctx context.Context, changed func(id string)) error {
+	pubsub := r.client.Subscribe(ctx, apiKeyChangesChannel)
+	defer pubsub.Close()
+
+	messages := pubsub.ChannelWithSubscriptions()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			switch msg := msg.(type) {
+			case *redis.Subscription:
+				changed("")
+			case *redis.Message:
+				changed(msg.Payload)
+			}
+		}
+	}
+}
+
+// publishChange tells other instances to drop cached copies of a key. The
+// change itself is already stored, failures only delay invalidation until
+// cached entries expire.
+func (r *RedisApiKeyRepository) publishChange(ctx context.Context, id string) {
+	if err := r.client.Publish(ctx, apiKeyChangesChannel, id).Err(); err != nil {
+		r.log.Warn("Failed to publish API key change", "key_id", id, "error", err)
+	}
+}
+
+// isPartialApiKeyRecord reports whether a hash without a salt holds fields only
+// salted records have, such as a usage timestamp written after a revocation
+func isPartialApiKeyRecord(data map[string]string) bool {
+	for _, field := range []string{apiKeyFieldID, apiKeyFieldLastUsedAt, apiKeyFieldSecretHash} {
+		if _, ok := data[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func apiKeyRedisKey(id string) string {
+	return fmt.Sprintf("apikey:%s", id)
+}
+
+func apiKeyToHash(record *entities.ApiKey) map[string]interface{} {
+	return map[string]interface{}{
+		apiKeyFieldID:         record.ID,
+		apiKeyFieldSalt:       record.Salt,
+		apiKeyFieldSecretHash: record.SecretHash,
+		apiKeyFieldOwner:      record.Owner,
+		apiKeyFieldScopes:     strings.Join(record.Scopes, ","),
+		apiKeyFieldRoles:      strings.Join(record.Roles, ","),
+		apiKeyFieldPlan:       record.Plan,
+		apiKeyFieldCreatedAt:  formatTime(record.CreatedAt),
+		apiKeyFieldExpiresAt:  formatTime(record.ExpiresAt),
+		apiKeyFieldLastUsedAt: formatTime(record.LastUsedAt),
+		apiKeyFieldDisabled:   strconv.FormatBool(record.Disabled),
+	}
+}
+
+func apiKeyFromHash(data map[string]string) *entities.ApiKey {
+	disabled, _ := strconv.ParseBool(data[apiKeyFieldDisabled])
+
+	return &entities.ApiKey{
+		ID:         data[apiKeyFieldID],
+		Salt:       data[apiKeyFieldSalt],
+		SecretHash: data[apiKeyFieldSecretHash],
+		Owner:      data[apiKeyFieldOwner],
+		Scopes:     splitList(data[apiKeyFieldScopes]),
+		Roles:      splitList(data[apiKeyFieldRoles]),
+		Plan:       data[apiKeyFieldPlan],
+		CreatedAt:  parseTime(data[apiKeyFieldCreatedAt]),
+		ExpiresAt:  parseTime(data[apiKeyFieldExpiresAt]),
+		LastUsedAt: parseTime(data[apiKeyFieldLastUsedAt]),
+		Disabled:   disabled,
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func splitList(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
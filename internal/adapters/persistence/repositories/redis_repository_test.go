@@ -0,0 +1,197 @@
+package repositories_test
+
+import (
+	"api-gateway/internal/adapters/persistence/repositories"
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedisRepo(t *testing.T) (ports.ApiKeyRepository, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return repositories.NewRedisApiKeyRepository(client, logger.New("test")), server
+}
+
+func TestRedisApiKeyRepository_StoresSaltedHash(t *testing.T) {
+	repo, server := newRedisRepo(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreKey(ctx, "k7f3a9.s3cret", &entities.ApiKey{Owner: "billing", Scopes: []string{"orders:read"}}))
+
+	assert.False(t, server.Exists("apikey:k7f3a9.s3cret"))
+	assert.NotContains(t, server.HGet("apikey:k7f3a9", "secret_hash"), "s3cret")
+	assert.NotEmpty(t, server.HGet("apikey:k7f3a9", "salt"))
+
+	record, err := repo.FindKey(ctx, "k7f3a9.s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, "k7f3a9", record.ID)
+	assert.Equal(t, []string{"orders:read"}, record.Scopes)
+
+	_, err = repo.FindKey(ctx, "k7f3a9.wrong")
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+
+	metadata, err := repo.GetKeyMetadata(ctx, "k7f3a9")
+	require.NoError(t, err)
+	assert.NotContains(t, metadata, "salt")
+	assert.NotContains(t, metadata, "secret_hash")
+}
+
+func TestRedisApiKeyRepository_MigrateLegacyKeys(t *testing.T) {
+	repo, server := newRedisRepo(t)
+	ctx := context.Background()
+
+	server.HSet("apikey:plain-key", "owner", "billing", "scopes", "orders:read", "disabled", "false")
+	server.HSet("apikey:old.format", "user", "reports")
+
+	migrator, ok := repo.(ports.ApiKeyMigrator)
+	require.True(t, ok)
+
+	migrated, err := migrator.MigrateLegacyKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, migrated)
+
+	assert.False(t, server.Exists("apikey:plain-key"))
+	assert.False(t, server.Exists("apikey:old.format"))
+
+	record, err := repo.FindKey(ctx, "plain-key")
+	require.NoError(t, err)
+	assert.Equal(t, entities.LegacyApiKeyID("plain-key"), record.ID)
+	assert.Equal(t, "billing", record.Owner)
+	assert.Equal(t, []string{"orders:read"}, record.Scopes)
+
+	record, err = repo.FindKey(ctx, "old.format")
+	require.NoError(t, err)
+	assert.Equal(t, "old", record.ID)
+	assert.Equal(t, "legacy", record.Owner)
+
+	migrated, err = migrator.MigrateLegacyKeys(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, migrated, "salted records must not be migrated again")
+}
+
+func TestRedisApiKeyRepository_RevokedKeyStaysRevoked(t *testing.T) {
+	repo, server := newRedisRepo(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing"}))
+	record, err := repo.GetKey(ctx, "k1")
+	require.NoError(t, err)
+	require.NoError(t, repo.RevokeKey(ctx, "k1"))
+
+	// Usage of a request authenticated just before the revocation arrives late
+	recorder, ok := repo.(ports.ApiKeyUsageRecorder)
+	require.True(t, ok)
+	recorder.RecordKeyUsage(ctx, "k1")
+	assert.False(t, server.Exists("apikey:k1"), "recording usage must not recreate a revoked key")
+
+	record.Disabled = true
+	assert.ErrorIs(t, repo.UpdateKey(ctx, record), domainErrors.ErrApiKeyNotFound)
+	assert.False(t, server.Exists("apikey:k1"))
+
+	// Hashes left behind by older instances are no plaintext keys
+	server.HSet("apikey:k1", "last_used_at", "2026-01-01T00:00:00Z")
+	server.HSet("apikey:k2", "owner", "billing")
+	server.Set("apikey_revoked:k2", "2026-01-01T00:00:00Z")
+	migrated, err := repo.(ports.ApiKeyMigrator).MigrateLegacyKeys(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, migrated)
+
+	_, err = repo.FindKey(ctx, "k1")
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+	_, err = repo.FindKey(ctx, "k2")
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+}
+
+func TestRedisApiKeyRepository_Lifecycle(t *testing.T) {
+	repo, _ := newRedisRepo(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreKey(ctx, "k1.one", &entities.ApiKey{Owner: "billing"}))
+	require.NoError(t, repo.StoreKey(ctx, "k2.two", &entities.ApiKey{Owner: "reports"}))
+
+	records, err := repo.ListKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	record, err := repo.GetKey(ctx, "k1")
+	require.NoError(t, err)
+	record.Disabled = true
+	require.NoError(t, repo.UpdateKey(ctx, record))
+
+	valid, err := repo.IsValidKey(ctx, "k1.one")
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	require.NoError(t, repo.RevokeKey(ctx, "k2"))
+	_, err = repo.GetKey(ctx, "k2")
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+	assert.ErrorIs(t, repo.RevokeKey(ctx, "k2"), domainErrors.ErrApiKeyNotFound)
+	assert.ErrorIs(t, repo.UpdateKey(ctx, &entities.ApiKey{ID: "k3", Owner: "x", Salt: "s", SecretHash: "h"}), domainErrors.ErrApiKeyNotFound)
+}
+
+func TestRedisApiKeyRepository_SeedKey(t *testing.T) {
+	repo, _ := newRedisRepo(t)
+	ctx := context.Background()
+
+	seed := func() *entities.ApiKey {
+		record := &entities.ApiKey{ID: "k1", Owner: "billing"}
+		require.NoError(t, record.SetSecret("s3cret"))
+		return record
+	}
+
+	stored, err := repo.SeedKey(ctx, seed())
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	valid, err := repo.IsValidKey(ctx, "k1.s3cret")
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	stored, err = repo.SeedKey(ctx, seed())
+	require.NoError(t, err)
+	assert.False(t, stored, "existing keys must not be overwritten")
+
+	require.NoError(t, repo.RevokeKey(ctx, "k1"))
+	stored, err = repo.SeedKey(ctx, seed())
+	require.NoError(t, err)
+	assert.False(t, stored, "revoked keys must not be seeded again")
+
+	valid, err = repo.IsValidKey(ctx, "k1.s3cret")
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestRedisApiKeyRepository_RevokeUnknownKey(t *testing.T) {
+	repo, server := newRedisRepo(t)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, repo.RevokeKey(ctx, "k1"), domainErrors.ErrApiKeyNotFound)
+	assert.False(t, server.Exists("apikey_revoked:k1"), "revoking an unknown id leaves no tombstone")
+
+	record := &entities.ApiKey{ID: "k1", Owner: "billing"}
+	require.NoError(t, record.SetSecret("s3cret"))
+	stored, err := repo.SeedKey(ctx, record)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	require.NoError(t, repo.RevokeKey(ctx, "k1"))
+	assert.True(t, server.Exists("apikey_revoked:k1"))
+}
+
+func TestRedisApiKeyRepository_HealthCheckHasNoSideEffects(t *testing.T) {
+	repo, server := newRedisRepo(t)
+
+	require.NoError(t, repo.HealthCheck(context.Background()))
+	assert.Empty(t, server.Keys())
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"api-gateway/internal/domain/entities"
+	"time"
+)
+
+// ApiKeyActor identifies who performed an admin operation, for the audit log
+type ApiKeyActor struct {
+	Source    string
+	RequestID string
+	RemoteIP  string
+}
+
+type CreateApiKeyRequest struct {
+	Owner     string     `json:"owner"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	Roles     []string   `json:"roles,omitempty"`
+	Plan      string     `json:"plan,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ApiKeyResponse describes a stored key without any secret material
+type ApiKeyResponse struct {
+	ID         string     `json:"id"`
+	Owner      string     `json:"owner"`
+	Scopes     []string   `json:"scopes"`
+	Roles      []string   `json:"roles"`
+	Plan       string     `json:"plan,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Disabled   bool       `json:"disabled"`
+}
+
+// ApiKeySecretResponse is returned once when a key is created or rotated;
+// the key cannot be retrieved again afterwards
+type ApiKeySecretResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
+
+func NewApiKeyResponse(record *entities.ApiKey) ApiKeyResponse {
+	response := ApiKeyResponse{
+		ID:        record.ID,
+		Owner:     record.Owner,
+		Scopes:    record.Scopes,
+		Roles:     record.Roles,
+		Plan:      record.Plan,
+		CreatedAt: record.CreatedAt,
+		Disabled:  record.Disabled,
+	}
+	if response.Scopes == nil {
+		response.Scopes = []string{}
+	}
+	if response.Roles == nil {
+		response.Roles = []string{}
+	}
+	if !record.ExpiresAt.IsZero() {
+		expiresAt := record.ExpiresAt
+		response.ExpiresAt = &expiresAt
+	}
+	if !record.LastUsedAt.IsZero() {
+		lastUsedAt := record.LastUsedAt
+		response.LastUsedAt = &lastUsedAt
+	}
+	return response
+}
@@ -5,10 +5,15 @@ import "api-gateway/internal/domain/entities"
 type AuthRequest struct {
 	Headers map[string][]string
 	Policy  *entities.AuthPolicy
+	// Request carries the full request for policies that cover more than a token
+	Request *entities.HTTPRequest
 }
 
 type AuthResponse struct {
 	Authenticated bool
 	UserID        string
 	ErrorMessage  string
+	Principal     *entities.Principal
+	// MatchedPolicies lists the policy types that authenticated the request, in evaluation order
+	MatchedPolicies []string
 }
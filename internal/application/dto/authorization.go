@@ -0,0 +1,12 @@
+package dto
+
+import "api-gateway/internal/domain/entities"
+
+type AuthorizationRequest struct {
+	RouteID   string
+	Policy    *entities.AuthorizationPolicy
+	Principal *entities.Principal
+	// Request and PathParams are what the rule of the policy is evaluated against
+	Request    *entities.HTTPRequest
+	PathParams map[string]string
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "net/url"
+import (
+	"api-gateway/internal/domain/entities"
+	"net/url"
+)
 
 type GatewayRequest struct {
 	Path        string
@@ -9,6 +12,8 @@ type GatewayRequest struct {
 	Body        []byte
 	QueryParams url.Values
 	Host        string
+	// Backend is the backend of the matched route, its concurrency limit applies while forwarding
+	Backend *entities.Backend
 }
 
 type GatewayResponse struct {
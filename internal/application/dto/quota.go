@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"api-gateway/internal/domain/entities"
+	"time"
+)
+
+// QuotaUsageResponse is the usage of an API key in the current windows of its plan
+type QuotaUsageResponse struct {
+	KeyID   string             `json:"key_id"`
+	Plan    string             `json:"plan,omitempty"`
+	Windows []QuotaWindowUsage `json:"windows"`
+}
+
+type QuotaWindowUsage struct {
+	Period    string    `json:"period"`
+	Window    string    `json:"window"`
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+func NewQuotaWindowUsage(usage entities.QuotaUsage) QuotaWindowUsage {
+	return QuotaWindowUsage{
+		Period:    string(usage.Period),
+		Window:    usage.ID,
+		Limit:     usage.Limit,
+		Used:      usage.Used,
+		Remaining: usage.Remaining(),
+		ResetAt:   usage.ResetAt,
+	}
+}
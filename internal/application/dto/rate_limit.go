@@ -0,0 +1,10 @@
+package dto
+
+import "api-gateway/internal/domain/entities"
+
+type RateLimitRequest struct {
+	RouteID   string
+	Limit     *entities.RateLimit
+	Principal *entities.Principal
+	ClientIP  string
+}
@@ -1,14 +1,49 @@
 package ports
 
-import "context"
+import (
+	"api-gateway/internal/domain/entities"
+	"context"
+)
 
+// ApiKeyRepository stores API keys as "<id>.<secret>" where only a salted hash
+// of the secret is persisted; lookups go by id and verify the secret
 type ApiKeyRepository interface {
-	// IsValidKey checks if an API key is valid
+	// IsValidKey checks if an API key exists, is enabled and has not expired
 	IsValidKey(ctx context.Context, key string) (bool, error)
 	HealthCheck(ctx context.Context) error
-	// GetKeyMetadata returns metadata about the key (user, permissions, etc.)
-	GetKeyMetadata(ctx context.Context, key string) (map[string]interface{}, error)
+	// FindKey returns the stored record of an API key if its secret matches
+	FindKey(ctx context.Context, key string) (*entities.ApiKey, error)
+	// GetKey returns the stored record for an id without checking a secret
+	GetKey(ctx context.Context, id string) (*entities.ApiKey, error)
+	// ListKeys returns all stored records
+	ListKeys(ctx context.Context) ([]*entities.ApiKey, error)
+	// GetKeyMetadata returns metadata about the key (user, permissions, etc.) by its id
+	GetKeyMetadata(ctx context.Context, id string) (map[string]interface{}, error)
 
-	StoreKey(ctx context.Context, key string, metadata map[string]interface{}) error
-	RevokeKey(ctx context.Context, key string) error
+	StoreKey(ctx context.Context, key string, record *entities.ApiKey) error
+	// SeedKey stores a pre-hashed record unless the id is already stored or was revoked
+	SeedKey(ctx context.Context, record *entities.ApiKey) (bool, error)
+	// UpdateKey persists changes to an existing record, including a rotated secret hash
+	UpdateKey(ctx context.Context, record *entities.ApiKey) error
+	RevokeKey(ctx context.Context, id string) error
+}
+
+// ApiKeyMigrator is implemented by stores that may still hold keys in plaintext
+type ApiKeyMigrator interface {
+	// MigrateLegacyKeys rehashes plaintext keys and returns how many were migrated
+	MigrateLegacyKeys(ctx context.Context) (int, error)
+}
+
+// ApiKeyUsageRecorder is implemented by stores that track when a key was last used
+type ApiKeyUsageRecorder interface {
+	// RecordKeyUsage stores the current time as last use of a key, failures are only logged
+	RecordKeyUsage(ctx context.Context, id string)
+}
+
+// ApiKeyChangeNotifier is implemented by stores shared between gateway instances
+type ApiKeyChangeNotifier interface {
+	// WatchKeyChanges calls changed with the id of every key created, updated or
+	// revoked by any instance until ctx is done. An empty id means changes may
+	// have been missed, e.g. after a reconnect.
+	WatchKeyChanges(ctx context.Context, changed func(id string)) error
 }
@@ -6,6 +6,10 @@ import (
 )
 
 type AuthValidator interface {
-	Validate(ctx context.Context, token string, policy *entities.AuthPolicy) error
+	// Validate verifies the token against the policy and returns the authenticated principal
+	Validate(ctx context.Context, token string, policy *entities.AuthPolicy) (*entities.Principal, error)
 	ExtractToken(ctx context.Context, headers map[string][]string, authType string) (string, error)
+	// ValidateRequest verifies policies that cover the whole request, such as
+	// request signatures, and returns the authenticated principal
+	ValidateRequest(ctx context.Context, req *entities.HTTPRequest, policy *entities.AuthPolicy) (*entities.Principal, error)
 }
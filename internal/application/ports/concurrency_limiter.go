@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"api-gateway/internal/domain/entities"
+	"context"
+	"time"
+)
+
+// ConcurrencyRelease frees the slot of a finished request, reporting how it
+// went so that adaptive limits can follow the backend
+type ConcurrencyRelease func(outcome entities.ConcurrencyOutcome, latency time.Duration)
+
+// ConcurrencyLimiter bounds the requests in flight, one limit per key
+type ConcurrencyLimiter interface {
+	// Acquire takes a slot of key, waiting in its queue while all are taken. Fails
+	// with ErrBackendOverloaded when the queue is full or the wait runs out.
+	Acquire(ctx context.Context, key string, limit *entities.ConcurrencyLimit) (ConcurrencyRelease, error)
+}
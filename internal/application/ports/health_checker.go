@@ -0,0 +1,17 @@
+package ports
+
+import "api-gateway/internal/domain/entities"
+
+// HealthChecker tells which backend targets pass their health checks and
+// ejects the ones failing live traffic. It is the only source of health:
+// the backends handed out with routes are not updated, so code deciding
+// where to send requests has to ask the checker.
+type HealthChecker interface {
+	// IsHealthy reports whether a target of a backend may take requests, targets
+	// of backends without health checks or outlier detection always may
+	IsHealthy(backendID, host string) bool
+	// Observe records how a request served by a target went
+	Observe(backendID, host string, outcome entities.TargetOutcome)
+	// Status returns the health of every checked backend
+	Status() []entities.BackendHealth
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "api-gateway/internal/domain/entities"
+
+// IdentitySigner issues the short-lived token that tells a backend who the caller is
+type IdentitySigner interface {
+	// Sign returns a token for the principal, addressed to the backend with the given id
+	Sign(principal *entities.Principal, audience string) (string, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "api-gateway/internal/domain/entities"
+
+// TargetRelease hands a target back once the request to it finished, telling
+// how it went. Calling it again has no effect.
+type TargetRelease func(outcome entities.TargetOutcome)
+
+// LoadBalancer picks the target of a backend that serves a request
+type LoadBalancer interface {
+	// Pick returns a target of the backend and the function to release it with.
+	// Requests with the same hash key go to the same target when the backend
+	// balances by consistent hashing. Fails with ErrBackendUnavailable when no
+	// target can take the request.
+	Pick(backend *entities.Backend, hashKey string) (*entities.Target, TargetRelease, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// NonceStore remembers nonces of signed requests so a captured request cannot be replayed
+type NonceStore interface {
+	// Remember stores a nonce for ttl and reports false when it was already stored
+	Remember(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
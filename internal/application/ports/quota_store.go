@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"api-gateway/internal/domain/entities"
+	"context"
+)
+
+// QuotaStore counts the requests of API keys per quota window
+type QuotaStore interface {
+	// Consume counts one request in every window unless one of them is exhausted
+	// already, in which case nothing is counted. Returns whether the request was
+	// counted and the usage of every window afterwards.
+	Consume(ctx context.Context, keyID string, windows []entities.QuotaWindow) ([]entities.QuotaUsage, bool, error)
+	// Usage returns the requests counted in every window
+	Usage(ctx context.Context, keyID string, windows []entities.QuotaWindow) ([]entities.QuotaUsage, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"api-gateway/internal/domain/entities"
+	"context"
+)
+
+// RateLimiter counts requests against a limit, one bucket per key
+type RateLimiter interface {
+	// Allow takes one request from the bucket of key
+	Allow(ctx context.Context, key string, limit *entities.RateLimit) (*entities.RateLimitDecision, error)
+}
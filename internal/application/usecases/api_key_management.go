@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"api-gateway/internal/application/dto"
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+// ApiKeyManagementUseCases covers the lifecycle of API keys for the admin API and CLI.
+// Every mutation is written to the audit log.
+type ApiKeyManagementUseCases interface {
+	Create(ctx context.Context, actor dto.ApiKeyActor, req *dto.CreateApiKeyRequest) (*dto.ApiKeySecretResponse, error)
+	List(ctx context.Context) ([]dto.ApiKeyResponse, error)
+	Get(ctx context.Context, id string) (*dto.ApiKeyResponse, error)
+	Rotate(ctx context.Context, actor dto.ApiKeyActor, id string) (*dto.ApiKeySecretResponse, error)
+	Disable(ctx context.Context, actor dto.ApiKeyActor, id string) (*dto.ApiKeyResponse, error)
+	Revoke(ctx context.Context, actor dto.ApiKeyActor, id string) error
+	// Seed stores pre-hashed keys that were never stored or revoked and returns how many were added
+	Seed(ctx context.Context, actor dto.ApiKeyActor, records []*entities.ApiKey) (int, error)
+}
+
+// apiKeyManagementUseCasesImpl implements ApiKeyManagementUseCases interface
+type apiKeyManagementUseCasesImpl struct {
+	apiKeyRepo ports.ApiKeyRepository
+	logger     logger.Logger
+	audit      logger.Logger
+}
+
+// NewApiKeyManagementUseCase creates a new instance of API key management use cases
+func NewApiKeyManagementUseCase(apiKeyRepo ports.ApiKeyRepository, log logger.Logger) ApiKeyManagementUseCases {
+	log.Info("Initializing api key management use case")
+
+	return &apiKeyManagementUseCasesImpl{
+		apiKeyRepo: apiKeyRepo,
+		logger:     log.With("component", "api_key_management_usecases"),
+		audit:      log.With("component", "audit"),
+	}
+}
+
+func (a apiKeyManagementUseCasesImpl) Create(ctx context.Context, actor dto.ApiKeyActor, req *dto.CreateApiKeyRequest) (*dto.ApiKeySecretResponse, error) {
+	if req.Owner == "" {
+		return nil, domainErrors.ErrApiKeyMissingOwner
+	}
+
+	id, secret, err := entities.GenerateApiKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	record := &entities.ApiKey{
+		ID:        id,
+		Owner:     req.Owner,
+		Scopes:    req.Scopes,
+		Roles:     req.Roles,
+		Plan:      req.Plan,
+		CreatedAt: time.Now(),
+	}
+	if req.ExpiresAt != nil {
+		record.ExpiresAt = *req.ExpiresAt
+	}
+
+	key := entities.FormatApiKey(id, secret)
+	if err := a.apiKeyRepo.StoreKey(ctx, key, record); err != nil {
+		a.logger.Error("Failed to create api key", "owner", req.Owner, "error", err)
+		return nil, err
+	}
+
+	a.auditMutation("create", actor, record)
+	return &dto.ApiKeySecretResponse{ApiKeyResponse: dto.NewApiKeyResponse(record), Key: key}, nil
+}
+
+func (a apiKeyManagementUseCasesImpl) List(ctx context.Context) ([]dto.ApiKeyResponse, error) {
+	records, err := a.apiKeyRepo.ListKeys(ctx)
+	if err != nil {
+		a.logger.Error("Failed to list api keys", "error", err)
+		return nil, err
+	}
+
+	responses := make([]dto.ApiKeyResponse, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, dto.NewApiKeyResponse(record))
+	}
+	return responses, nil
+}
+
+func (a apiKeyManagementUseCasesImpl) Get(ctx context.Context, id string) (*dto.ApiKeyResponse, error) {
+	record, err := a.apiKeyRepo.GetKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.NewApiKeyResponse(record)
+	return &response, nil
+}
+
+// Rotate replaces the secret of a key and keeps its id, owner and grants
+func (a apiKeyManagementUseCasesImpl) Rotate(ctx context.Context, actor dto.ApiKeyActor, id string) (*dto.ApiKeySecretResponse, error) {
+	record, err := a.apiKeyRepo.GetKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, secret, err := entities.GenerateApiKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	if err := record.SetSecret(secret); err != nil {
+		return nil, fmt.Errorf("failed to hash api key secret: %w", err)
+	}
+
+	if err := a.apiKeyRepo.UpdateKey(ctx, record); err != nil {
+		a.logger.Error("Failed to rotate api key", "key_id", id, "error", err)
+		return nil, err
+	}
+
+	a.auditMutation("rotate", actor, record)
+	return &dto.ApiKeySecretResponse{ApiKeyResponse: dto.NewApiKeyResponse(record), Key: entities.FormatApiKey(id, secret)}, nil
+}
+
+func (a apiKeyManagementUseCasesImpl) Disable(ctx context.Context, actor dto.ApiKeyActor, id string) (*dto.ApiKeyResponse, error) {
+	record, err := a.apiKeyRepo.GetKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Disabled = true
+	if err := a.apiKeyRepo.UpdateKey(ctx, record); err != nil {
+		a.logger.Error("Failed to disable api key", "key_id", id, "error", err)
+		return nil, err
+	}
+
+	a.auditMutation("disable", actor, record)
+	response := dto.NewApiKeyResponse(record)
+	return &response, nil
+}
+
+func (a apiKeyManagementUseCasesImpl) Revoke(ctx context.Context, actor dto.ApiKeyActor, id string) error {
+	record, err := a.apiKeyRepo.GetKey(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := a.apiKeyRepo.RevokeKey(ctx, id); err != nil {
+		a.logger.Error("Failed to revoke api key", "key_id", id, "error", err)
+		return err
+	}
+
+	a.auditMutation("revoke", actor, record)
+	return nil
+}
+
+func (a apiKeyManagementUseCasesImpl) Seed(ctx context.Context, actor dto.ApiKeyActor, records []*entities.ApiKey) (int, error) {
+	seeded := 0
+	for _, record := range records {
+		stored, err := a.apiKeyRepo.SeedKey(ctx, record)
+		if err != nil {
+			a.logger.Error("Failed to seed api key", "key_id", record.ID, "error", err)
+			return seeded, fmt.Errorf("api key seed %s: %w", record.ID, err)
+		}
+		if !stored {
+			continue
+		}
+
+		seeded++
+		a.auditMutation("seed", actor, record)
+	}
+	return seeded, nil
+}
+
+func (a apiKeyManagementUseCasesImpl) auditMutation(action string, actor dto.ApiKeyActor, record *entities.ApiKey) {
+	a.audit.Info("API key audit",
+		"action", action,
+		"key_id", record.ID,
+		"owner", record.Owner,
+		"scopes", record.Scopes,
+		"roles", record.Roles,
+		"plan", record.Plan,
+		"disabled", record.Disabled,
+		"source", actor.Source,
+		"request_id", actor.RequestID,
+		"remote_ip", actor.RemoteIP,
+	)
+}
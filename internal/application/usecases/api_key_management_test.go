@@ -0,0 +1,213 @@
+package usecases_test
+
+import (
+	"api-gateway/pkg/logger"
+	"context"
+	"testing"
+
+	"api-gateway/internal/application/dto"
+	"api-gateway/internal/application/usecases"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockApiKeyRepository is a mock for the ApiKeyRepository port
+type MockApiKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockApiKeyRepository) IsValidKey(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) HealthCheck(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func (m *MockApiKeyRepository) FindKey(ctx context.Context, key string) (*entities.ApiKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) GetKey(ctx context.Context, id string) (*entities.ApiKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) ListKeys(ctx context.Context) ([]*entities.ApiKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) GetKeyMetadata(ctx context.Context, id string) (map[string]interface{}, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) StoreKey(ctx context.Context, key string, record *entities.ApiKey) error {
+	return m.Called(ctx, key, record).Error(0)
+}
+
+func (m *MockApiKeyRepository) SeedKey(ctx context.Context, record *entities.ApiKey) (bool, error) {
+	args := m.Called(ctx, record)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockApiKeyRepository) UpdateKey(ctx context.Context, record *entities.ApiKey) error {
+	return m.Called(ctx, record).Error(0)
+}
+
+func (m *MockApiKeyRepository) RevokeKey(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func storedApiKey(t *testing.T, id string) *entities.ApiKey {
+	t.Helper()
+	record := &entities.ApiKey{ID: id, Owner: "billing", Scopes: []string{"orders:read"}}
+	require.NoError(t, record.SetSecret("old-secret"))
+	return record
+}
+
+func TestApiKeyManagementUseCase_Create(t *testing.T) {
+	mockRepo := new(MockApiKeyRepository)
+	mockRepo.On("StoreKey", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	useCase := usecases.NewApiKeyManagementUseCase(mockRepo, logger.New("test"))
+
+	response, err := useCase.Create(context.Background(), dto.ApiKeyActor{RequestID: "req-1"}, &dto.CreateApiKeyRequest{
+		Owner:  "billing",
+		Scopes: []string{"orders:read"},
+	})
+
+	require.NoError(t, err)
+	id, secret := entities.ParseApiKey(response.Key)
+	assert.Equal(t, response.ID, id)
+	assert.NotEmpty(t, secret)
+	assert.Equal(t, "billing", response.Owner)
+	assert.Equal(t, []string{"orders:read"}, response.Scopes)
+
+	stored := mockRepo.Calls[0].Arguments.Get(2).(*entities.ApiKey)
+	assert.Equal(t, response.Key, mockRepo.Calls[0].Arguments.String(1))
+	assert.Equal(t, "billing", stored.Owner)
+}
+
+func TestApiKeyManagementUseCase_Create_MissingOwner(t *testing.T) {
+	mockRepo := new(MockApiKeyRepository)
+	useCase := usecases.NewApiKeyManagementUseCase(mockRepo, logger.New("test"))
+
+	response, err := useCase.Create(context.Background(), dto.ApiKeyActor{}, &dto.CreateApiKeyRequest{})
+
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyMissingOwner)
+	assert.Nil(t, response)
+	mockRepo.AssertNotCalled(t, "StoreKey", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApiKeyManagementUseCase_Rotate(t *testing.T) {
+	mockRepo := new(MockApiKeyRepository)
+	record := storedApiKey(t, "k1")
+	oldHash := record.SecretHash
+
+	mockRepo.On("GetKey", mock.Anything, "k1").Return(record, nil)
+	mockRepo.On("UpdateKey", mock.Anything, record).Return(nil)
+
+	useCase := usecases.NewApiKeyManagementUseCase(mockRepo, logger.New("test"))
+
+	response, err := useCase.Rotate(context.Background(), dto.ApiKeyActor{}, "k1")
+
+	require.NoError(t, err)
+	id, secret := entities.ParseApiKey(response.Key)
+	assert.Equal(t, "k1", id)
+	assert.NotEqual(t, oldHash, record.SecretHash)
+	assert.True(t, record.VerifySecret(secret))
+	assert.False(t, record.VerifySecret("old-secret"))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApiKeyManagementUseCase_Disable(t *testing.T) {
+	mockRepo := new(MockApiKeyRepository)
+	record := storedApiKey(t, "k1")
+
+	mockRepo.On("GetKey", mock.Anything, "k1").Return(record, nil)
+	mockRepo.On("UpdateKey", mock.Anything, record).Return(nil)
+
+	useCase := usecases.NewApiKeyManagementUseCase(mockRepo, logger.New("test"))
+
+	response, err := useCase.Disable(context.Background(), dto.ApiKeyActor{}, "k1")
+
+	require.NoError(t, err)
+	assert.True(t, response.Disabled)
+	assert.True(t, record.Disabled)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApiKeyManagementUseCase_Revoke_NotFound(t *testing.T) {
+	mockRepo := new(MockApiKeyRepository)
+	mockRepo.On("GetKey", mock.Anything, "missing").Return(nil, domainErrors.ErrApiKeyNotFound)
+
+	useCase := usecases.NewApiKeyManagementUseCase(mockRepo, logger.New("test"))
+
+	err := useCase.Revoke(context.Background(), dto.ApiKeyActor{}, "missing")
+
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+	mockRepo.AssertNotCalled(t, "RevokeKey", mock.Anything, mock.Anything)
+}
+
+func TestApiKeyManagementUseCase_List(t *testing.T) {
+	mockRepo := new(MockApiKeyRepository)
+	mockRepo.On("ListKeys", mock.Anything).Return([]*entities.ApiKey{storedApiKey(t, "k1"), storedApiKey(t, "k2")}, nil)
+
+	useCase := usecases.NewApiKeyManagementUseCase(mockRepo, logger.New("test"))
+
+	response, err := useCase.List(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, response, 2)
+	assert.Equal(t, "k1", response[0].ID)
+	assert.Equal(t, "k2", response[1].ID)
+}
+
+func TestApiKeyManagementUseCase_Seed(t *testing.T) {
+	mockRepo := new(MockApiKeyRepository)
+	fresh := storedApiKey(t, "k1")
+	revoked := storedApiKey(t, "k2")
+
+	mockRepo.On("SeedKey", mock.Anything, fresh).Return(true, nil)
+	mockRepo.On("SeedKey", mock.Anything, revoked).Return(false, nil)
+
+	useCase := usecases.NewApiKeyManagementUseCase(mockRepo, logger.New("test"))
+
+	seeded, err := useCase.Seed(context.Background(), dto.ApiKeyActor{Source: "bootstrap"}, []*entities.ApiKey{fresh, revoked})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, seeded)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApiKeyManagementUseCase_Seed_InvalidRecord(t *testing.T) {
+	mockRepo := new(MockApiKeyRepository)
+	invalid := &entities.ApiKey{ID: "k1", Owner: "billing"}
+	mockRepo.On("SeedKey", mock.Anything, invalid).Return(false, domainErrors.ErrApiKeyMissingSecret)
+
+	useCase := usecases.NewApiKeyManagementUseCase(mockRepo, logger.New("test"))
+
+	_, err := useCase.Seed(context.Background(), dto.ApiKeyActor{}, []*entities.ApiKey{invalid})
+
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyMissingSecret)
+}
@@ -4,8 +4,10 @@ import (
 	"api-gateway/internal/application/dto"
 	"api-gateway/internal/application/ports"
 	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
 	"api-gateway/pkg/logger"
 	"context"
+	"errors"
 	"time"
 )
 
@@ -65,69 +67,222 @@ func (a authenticationUseCasesImpl) Execute(ctx context.Context, req *dto.AuthRe
 		"policy_enabled", req.Policy.Enabled,
 	)
 
-	// Handle API Key authentication
-	if req.Policy.Type == entities.AuthTypeAPIKey {
-		a.logger.Info("Processing API Key authentication",
+	if !isSupportedAuthType(req.Policy.Type) {
+		a.logger.Warn("Unsupported authentication policy type",
 			"policy_type", req.Policy.Type,
+			"duration_ms", time.Since(startTime).Milliseconds(),
 		)
+		return nil, domainErrors.ErrUnsupportedAuthType
+	}
 
-		a.logger.Debug("Extracting token from headers",
-			"policy_type", req.Policy.Type,
-			"headers_count", len(req.Headers),
-		)
+	var principal *entities.Principal
+	var matched []string
+	var err error
+	if req.Policy.Type == entities.AuthTypeComposite {
+		principal, matched, err = a.authenticateComposite(ctx, req, startTime)
+	} else {
+		principal, err = a.authenticatePolicy(ctx, req, req.Policy, startTime)
+		matched = []string{req.Policy.Type}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.Info("Token validated successfully",
+		"policy_type", req.Policy.Type,
+		"authenticated", true,
+	)
+
+	authRespone = dto.AuthResponse{
+		Authenticated:   true,
+		UserID:          "",
+		ErrorMessage:    "",
+		Principal:       principal,
+		MatchedPolicies: matched,
+	}
+	if principal != nil {
+		authRespone.UserID = principal.Subject
+	}
+
+	a.logger.Info("Authentication successful",
+		"policy_type", req.Policy.Type,
+		"authenticated", authRespone.Authenticated,
+		"user_id", authRespone.UserID,
+		"matched_policies", matched,
+		"duration_ms", time.Since(startTime).Milliseconds(),
+	)
+
+	return &authRespone, nil
+}
 
-		token, err := a.authValidator.ExtractToken(ctx, req.Headers, req.Policy.Type)
+// authenticateComposite evaluates the policies of a composite in order. any_of
+// returns the principal of the first policy that succeeds; all_of requires
+// every policy and returns the principal of the first one, which later
+// policies such as external authorization see on the request.
+func (a authenticationUseCasesImpl) authenticateComposite(ctx context.Context, req *dto.AuthRequest, startTime time.Time) (*entities.Principal, []string, error) {
+	var principal *entities.Principal
+	var matched []string
+	var failure error
+
+	for _, policy := range req.Policy.Policies {
+		policyReq := req
+		if principal != nil && req.Request != nil {
+			request := *req.Request
+			request.Principal = principal
+			withPrincipal := *req
+			withPrincipal.Request = &request
+			policyReq = &withPrincipal
+		}
+
+		policyPrincipal, err := a.authenticatePolicy(ctx, policyReq, policy, startTime)
 		if err != nil {
-			a.logger.Warn("Token extraction failed",
-				"error", err,
-				"policy_type", req.Policy.Type,
-				"duration_ms", time.Since(startTime).Milliseconds(),
-			)
-			return nil, err
+			if !req.Policy.MatchesAny() {
+				return nil, nil, err
+			}
+			// Report why presented credentials failed rather than that another policy's were absent
+			if failure == nil || (isMissingCredentials(failure) && !isMissingCredentials(err)) {
+				failure = err
+			}
+			continue
 		}
 
-		a.logger.Debug("Token extracted successfully",
-			"token_length", len(token),
-			"policy_type", req.Policy.Type,
+		matched = append(matched, policy.Type)
+		if principal == nil {
+			principal = policyPrincipal
+		} else if policyPrincipal.Upstream != nil {
+			// Keep header changes requested by later policies, such as an external authorization service
+			merged := *principal
+			merged.Upstream = principal.Upstream.Merge(policyPrincipal.Upstream)
+			principal = &merged
+		}
+		if req.Policy.MatchesAny() {
+			break
+		}
+	}
+
+	if principal == nil {
+		if failure == nil {
+			failure = domainErrors.ErrAuthPolicyMissingPolicies
+		}
+		a.logger.Warn("Composite policy not satisfied",
+			"match", req.Policy.Match,
+			"error", failure,
+			"duration_ms", time.Since(startTime).Milliseconds(),
 		)
+		return nil, nil, failure
+	}
 
-		a.logger.Info("Validating token",
-			"policy_type", req.Policy.Type,
+	a.logger.Info("Composite policy satisfied",
+		"match", req.Policy.Match,
+		"matched_policies", matched,
+	)
+	return principal, matched, nil
+}
+
+// authenticatePolicy validates a single, non-composite policy
+func (a authenticationUseCasesImpl) authenticatePolicy(ctx context.Context, req *dto.AuthRequest, policy *entities.AuthPolicy, startTime time.Time) (*entities.Principal, error) {
+	if !isSupportedAuthType(policy.Type) || policy.Type == entities.AuthTypeComposite {
+		return nil, domainErrors.ErrUnsupportedAuthType
+	}
+	if isRequestAuthType(policy.Type) {
+		return a.authenticateRequest(ctx, req, policy, startTime)
+	}
+	return a.authenticateToken(ctx, req, policy, startTime)
+}
+
+// authenticateToken extracts the token of the policy type from the headers and validates it
+func (a authenticationUseCasesImpl) authenticateToken(ctx context.Context, req *dto.AuthRequest, policy *entities.AuthPolicy, startTime time.Time) (*entities.Principal, error) {
+	a.logger.Info("Processing token authentication",
+		"policy_type", policy.Type,
+	)
+
+	a.logger.Debug("Extracting token from headers",
+		"policy_type", policy.Type,
+		"headers_count", len(req.Headers),
+	)
+
+	token, err := a.authValidator.ExtractToken(ctx, req.Headers, policy.Type)
+	if err != nil {
+		a.logger.Warn("Token extraction failed",
+			"error", err,
+			"policy_type", policy.Type,
+			"duration_ms", time.Since(startTime).Milliseconds(),
 		)
+		return nil, err
+	}
 
-		err = a.authValidator.Validate(ctx, token, req.Policy)
-		if err != nil {
-			a.logger.Warn("Token validation failed",
-				"error", err,
-				"policy_type", req.Policy.Type,
-				"duration_ms", time.Since(startTime).Milliseconds(),
-			)
-			return nil, err
-		}
+	a.logger.Debug("Token extracted successfully",
+		"token_length", len(token),
+		"policy_type", policy.Type,
+	)
 
-		a.logger.Info("Token validated successfully",
-			"policy_type", req.Policy.Type,
-			"authenticated", true,
+	a.logger.Info("Validating token",
+		"policy_type", policy.Type,
+	)
+
+	principal, err := a.authValidator.Validate(ctx, token, policy)
+	if err != nil {
+		a.logger.Warn("Token validation failed",
+			"error", err,
+			"policy_type", policy.Type,
+			"duration_ms", time.Since(startTime).Milliseconds(),
 		)
+		return nil, err
+	}
+	return principal, nil
+}
 
-		authRespone = dto.AuthResponse{
-			Authenticated: true,
-			UserID:        "",
-			ErrorMessage:  "",
-		}
+// authenticateRequest validates policies that cover the whole request, such as request signatures, client certificates or external authorization
+func (a authenticationUseCasesImpl) authenticateRequest(ctx context.Context, req *dto.AuthRequest, policy *entities.AuthPolicy, startTime time.Time) (*entities.Principal, error) {
+	a.logger.Info("Validating request",
+		"policy_type", policy.Type,
+	)
 
-		a.logger.Info("API Key authentication successful",
-			"authenticated", authRespone.Authenticated,
+	if req.Request == nil {
+		a.logger.Warn("Request details missing for request policy",
+			"policy_type", policy.Type,
+		)
+		return nil, domainErrors.ErrUnsupportedAuthType
+	}
+
+	principal, err := a.authValidator.ValidateRequest(ctx, req.Request, policy)
+	if err != nil {
+		a.logger.Warn("Request validation failed",
+			"error", err,
+			"policy_type", policy.Type,
 			"duration_ms", time.Since(startTime).Milliseconds(),
 		)
+		return nil, err
+	}
+	return principal, nil
+}
 
-		return &authRespone, nil
+// isSupportedAuthType reports whether the policy type is backed by a validator
+func isSupportedAuthType(authType string) bool {
+	switch authType {
+	case entities.AuthTypeAPIKey, entities.AuthTypeJWT, entities.AuthTypeOAuth2Introspection, entities.AuthTypeHMAC, entities.AuthTypeMTLS, entities.AuthTypeBasic, entities.AuthTypeComposite, entities.AuthTypeExternal:
+		return true
 	}
+	return false
+}
 
-	a.logger.Warn("Unsupported authentication policy type",
-		"policy_type", req.Policy.Type,
-		"duration_ms", time.Since(startTime).Milliseconds(),
-	)
+// isRequestAuthType reports whether the policy type is validated against the whole request instead of a token
+func isRequestAuthType(authType string) bool {
+	return authType == entities.AuthTypeHMAC || authType == entities.AuthTypeMTLS || authType == entities.AuthTypeExternal
+}
 
-	return nil, nil
+// isMissingCredentials reports whether a policy failed because the request carried none of its credentials
+func isMissingCredentials(err error) bool {
+	for _, missing := range []error{
+		domainErrors.ErrMissingApiKey,
+		domainErrors.ErrMissingBearerToken,
+		domainErrors.ErrMissingBasicCredentials,
+		domainErrors.ErrMissingSignature,
+		domainErrors.ErrMissingClientCertificate,
+	} {
+		if errors.Is(err, missing) {
+			return true
+		}
+	}
+	return false
 }
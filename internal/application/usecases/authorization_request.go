@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"api-gateway/internal/application/dto"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"time"
+)
+
+// AuthorizationUseCases decides whether an authenticated principal may call a route
+type AuthorizationUseCases interface {
+	Execute(ctx context.Context, req *dto.AuthorizationRequest) error
+}
+
+// authorizationUseCasesImpl implements AuthorizationUseCases interface
+type authorizationUseCasesImpl struct {
+	logger logger.Logger
+}
+
+// NewAuthorizeRequestUseCase creates a new instance of authorization use cases
+func NewAuthorizeRequestUseCase(log logger.Logger) AuthorizationUseCases {
+	log.Info("Initializing authorization use case")
+
+	return &authorizationUseCasesImpl{
+		logger: log.With("component", "authorization_usecases"),
+	}
+}
+
+func (a authorizationUseCasesImpl) Execute(ctx context.Context, req *dto.AuthorizationRequest) error {
+	startTime := time.Now()
+
+	if req.Policy == nil || req.Policy.IsEmpty() {
+		a.logger.Debug("No authorization required",
+			"route_id", req.RouteID,
+		)
+		return nil
+	}
+
+	a.logger.Debug("Evaluating authorization policy",
+		"route_id", req.RouteID,
+		"required_scopes", req.Policy.RequiredScopes,
+		"required_roles", req.Policy.RequiredRoles,
+		"match", req.Policy.Match,
+		"rule", req.Policy.Rule,
+	)
+
+	if err := req.Policy.Authorize(req.Principal); err != nil {
+		a.logger.Warn("Authorization denied",
+			"route_id", req.RouteID,
+			"error", err,
+			"duration_ms", time.Since(startTime).Milliseconds(),
+		)
+		return err
+	}
+
+	if err := a.evaluateRule(req); err != nil {
+		a.logger.Warn("Authorization denied",
+			"route_id", req.RouteID,
+			"error", err,
+			"duration_ms", time.Since(startTime).Milliseconds(),
+		)
+		return err
+	}
+
+	a.logger.Info("Authorization granted",
+		"route_id", req.RouteID,
+		"duration_ms", time.Since(startTime).Milliseconds(),
+	)
+	return nil
+}
+
+// evaluateRule checks the rule of the policy, a rule that fails to evaluate denies the request
+func (a authorizationUseCasesImpl) evaluateRule(req *dto.AuthorizationRequest) error {
+	if req.Policy.Rule == nil {
+		return nil
+	}
+
+	allowed, err := req.Policy.Rule.Evaluate(entities.RuleInput{
+		Request:    req.Request,
+		PathParams: req.PathParams,
+		Principal:  req.Principal,
+	})
+	if err != nil {
+		a.logger.Error("Authorization rule evaluation failed",
+			"route_id", req.RouteID,
+			"rule", req.Policy.Rule,
+			"error", err,
+		)
+		return domainErrors.ErrAuthorizationRuleDenied
+	}
+	if !allowed {
+		return domainErrors.ErrAuthorizationRuleDenied
+	}
+	return nil
+}
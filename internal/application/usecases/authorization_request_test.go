@@ -0,0 +1,134 @@
+package usecases_test
+
+import (
+	"api-gateway/pkg/logger"
+	"context"
+	"testing"
+
+	"api-gateway/internal/application/dto"
+	"api-gateway/internal/application/usecases"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeRequestUseCase_Execute_NoPolicy(t *testing.T) {
+	useCase := usecases.NewAuthorizeRequestUseCase(logger.New("test"))
+
+	err := useCase.Execute(context.Background(), &dto.AuthorizationRequest{
+		RouteID: "orders-list",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestAuthorizeRequestUseCase_Execute_Granted(t *testing.T) {
+	useCase := usecases.NewAuthorizeRequestUseCase(logger.New("test"))
+
+	err := useCase.Execute(context.Background(), &dto.AuthorizationRequest{
+		RouteID: "orders-create",
+		Policy: &entities.AuthorizationPolicy{
+			RequiredScopes: []string{"orders:write"},
+		},
+		Principal: &entities.Principal{
+			Subject: "user-1",
+			Scopes:  []string{"orders:write"},
+		},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestAuthorizeRequestUseCase_Execute_Denied(t *testing.T) {
+	useCase := usecases.NewAuthorizeRequestUseCase(logger.New("test"))
+
+	err := useCase.Execute(context.Background(), &dto.AuthorizationRequest{
+		RouteID: "orders-delete",
+		Policy: &entities.AuthorizationPolicy{
+			RequiredScopes: []string{"orders:delete"},
+		},
+		Principal: &entities.Principal{
+			Subject: "user-1",
+			Scopes:  []string{"orders:read"},
+		},
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrInsufficientScope)
+}
+
+func TestAuthorizeRequestUseCase_Execute_Rule(t *testing.T) {
+	rule, err := entities.NewAuthorizationRule(`claims.tenant == path.params.tenantId && method in ["GET", "HEAD"]`)
+	assert.NoError(t, err)
+	failing, err := entities.NewAuthorizationRule(`claims.tenant < 3`)
+	assert.NoError(t, err)
+
+	acme := &entities.Principal{
+		Subject: "user-1",
+		Scopes:  []string{"orders:read"},
+		Claims:  map[string]interface{}{"tenant": "acme"},
+	}
+
+	tests := []struct {
+		name      string
+		policy    *entities.AuthorizationPolicy
+		method    string
+		principal *entities.Principal
+		wantErr   error
+	}{
+		{
+			name:      "rule holds",
+			policy:    &entities.AuthorizationPolicy{Rule: rule},
+			method:    "GET",
+			principal: acme,
+		},
+		{
+			name:      "rule does not hold for the method",
+			policy:    &entities.AuthorizationPolicy{Rule: rule},
+			method:    "DELETE",
+			principal: acme,
+			wantErr:   domainErrors.ErrAuthorizationRuleDenied,
+		},
+		{
+			name:      "rule without a principal",
+			policy:    &entities.AuthorizationPolicy{Rule: rule},
+			method:    "GET",
+			principal: nil,
+			wantErr:   domainErrors.ErrAuthorizationRuleDenied,
+		},
+		{
+			name:      "scopes are checked before the rule",
+			policy:    &entities.AuthorizationPolicy{RequiredScopes: []string{"orders:write"}, Rule: rule},
+			method:    "GET",
+			principal: acme,
+			wantErr:   domainErrors.ErrInsufficientScope,
+		},
+		{
+			name:      "evaluation error denies",
+			policy:    &entities.AuthorizationPolicy{Rule: failing},
+			method:    "GET",
+			principal: acme,
+			wantErr:   domainErrors.ErrAuthorizationRuleDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := usecases.NewAuthorizeRequestUseCase(logger.New("test"))
+
+			err := useCase.Execute(context.Background(), &dto.AuthorizationRequest{
+				RouteID:    "tenant-orders",
+				Policy:     tt.policy,
+				Principal:  tt.principal,
+				Request:    &entities.HTTPRequest{RouteID: "tenant-orders", Method: tt.method},
+				PathParams: map[string]string{"tenantId": "acme"},
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"api-gateway/internal/application/dto"
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+	"time"
+)
+
+// apiKeyPlanField is the key metadata field naming the quota plan of a key
+const apiKeyPlanField = "plan"
+
+// QuotaUseCases counts the requests of API keys against the quotas of their plan
+type QuotaUseCases interface {
+	// Execute counts a request of the calling key and returns ErrQuotaExceeded when
+	// a window of its plan is exhausted. Callers without an API key, keys on an
+	// unlimited plan and requests made while the store fails are allowed with a nil decision.
+	Execute(ctx context.Context, principal *entities.Principal) (*entities.QuotaDecision, error)
+	// Usage returns the usage of a key in the current windows of its plan
+	Usage(ctx context.Context, keyID string) (*dto.QuotaUsageResponse, error)
+}
+
+// quotaUseCasesImpl implements QuotaUseCases interface
+type quotaUseCasesImpl struct {
+	policy     *entities.QuotaPolicy
+	store      ports.QuotaStore
+	apiKeyRepo ports.ApiKeyRepository
+	logger     logger.Logger
+}
+
+// NewQuotaUseCase creates a new instance of quota use cases, a nil policy disables quotas
+func NewQuotaUseCase(policy *entities.QuotaPolicy, store ports.QuotaStore, apiKeyRepo ports.ApiKeyRepository, log logger.Logger) QuotaUseCases {
+	log.Info("Initializing quota use case", "enabled", policy != nil)
+
+	return &quotaUseCasesImpl{
+		policy:     policy,
+		store:      store,
+		apiKeyRepo: apiKeyRepo,
+		logger:     log.With("component", "quota_usecases"),
+	}
+}
+
+func (q quotaUseCasesImpl) Execute(ctx context.Context, principal *entities.Principal) (*entities.QuotaDecision, error) {
+	if q.policy == nil || principal == nil || principal.KeyID == "" {
+		return nil, nil
+	}
+
+	plan, err := q.plan(ctx, principal.KeyID)
+	if err != nil {
+		// Losing the quota store must not take the whole gateway down with it
+		q.logger.Error("Failed to resolve quota plan, allowing request",
+			"key_id", principal.KeyID,
+			"error", err,
+		)
+		return nil, nil
+	}
+	if plan == nil {
+		return nil, nil
+	}
+	windows := q.policy.Windows(plan, time.Now())
+	if len(windows) == 0 {
+		return nil, nil
+	}
+
+	usage, allowed, err := q.store.Consume(ctx, principal.KeyID, windows)
+	if err != nil {
+		q.logger.Error("Quota store failed, allowing request",
+			"key_id", principal.KeyID,
+			"plan", plan.Name,
+			"error", err,
+		)
+		return nil, nil
+	}
+
+	decision := &entities.QuotaDecision{Plan: plan.Name, Allowed: allowed, Usage: usage}
+	if !allowed {
+		q.logger.Warn("Usage quota exceeded",
+			"key_id", principal.KeyID,
+			"plan", plan.Name,
+			"retry_at", decision.RetryAt(),
+		)
+		return decision, domainErrors.ErrQuotaExceeded
+	}
+
+	q.logger.Debug("Usage quota passed",
+		"key_id", principal.KeyID,
+		"plan", plan.Name,
+	)
+	return decision, nil
+}
+
+func (q quotaUseCasesImpl) Usage(ctx context.Context, keyID string) (*dto.QuotaUsageResponse, error) {
+	plan, err := q.plan(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.QuotaUsageResponse{KeyID: keyID, Windows: []dto.QuotaWindowUsage{}}
+	if plan == nil {
+		return response, nil
+	}
+	response.Plan = plan.Name
+
+	usage, err := q.store.Usage(ctx, keyID, q.policy.Windows(plan, time.Now()))
+	if err != nil {
+		q.logger.Error("Failed to read quota usage", "key_id", keyID, "error", err)
+		return nil, err
+	}
+	for _, window := range usage {
+		response.Windows = append(response.Windows, dto.NewQuotaWindowUsage(window))
+	}
+	return response, nil
+}
+
+// plan looks up the plan stored in the metadata of a key, nil when quotas are
+// disabled or the key is not limited
+func (q quotaUseCasesImpl) plan(ctx context.Context, keyID string) (*entities.QuotaPlan, error) {
+	metadata, err := q.apiKeyRepo.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if q.policy == nil {
+		return nil, nil
+	}
+
+	name, _ := metadata[apiKeyPlanField].(string)
+	if name != "" && q.policy.Plans[name] == nil {
+		q.logger.Warn("API key plan is not configured, using the default plan",
+			"key_id", keyID,
+			"plan", name,
+			"default_plan", q.policy.DefaultPlan,
+		)
+	}
+	return q.policy.Plan(name), nil
+}
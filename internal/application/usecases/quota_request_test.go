@@ -0,0 +1,194 @@
+package usecases_test
+
+import (
+	"api-gateway/pkg/logger"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"api-gateway/internal/application/usecases"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockQuotaStore is a mock for the QuotaStore port
+type MockQuotaStore struct {
+	mock.Mock
+}
+
+func (m *MockQuotaStore) Consume(ctx context.Context, keyID string, windows []entities.QuotaWindow) ([]entities.QuotaUsage, bool, error) {
+	args := m.Called(ctx, keyID, windows)
+	if args.Error(2) != nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(quotaUsageFunc)(windows), args.Bool(1), nil
+}
+
+func (m *MockQuotaStore) Usage(ctx context.Context, keyID string, windows []entities.QuotaWindow) ([]entities.QuotaUsage, error) {
+	args := m.Called(ctx, keyID, windows)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(quotaUsageFunc)(windows), nil
+}
+
+func quotaPolicy() *entities.QuotaPolicy {
+	return &entities.QuotaPolicy{
+		Plans: map[string]*entities.QuotaPlan{
+			"free":      {Name: "free", Daily: 100, Monthly: 1000},
+			"unlimited": {Name: "unlimited"},
+		},
+		DefaultPlan: "free",
+		Location:    time.UTC,
+	}
+}
+
+// quotaUsageFunc computes the usage MockQuotaStore returns for the windows it is passed
+type quotaUsageFunc func(windows []entities.QuotaWindow) []entities.QuotaUsage
+
+// usageOf reports the same usage for every window
+func usageOf(used int64) quotaUsageFunc {
+	return func(windows []entities.QuotaWindow) []entities.QuotaUsage {
+		var usage []entities.QuotaUsage
+		for _, window := range windows {
+			usage = append(usage, entities.QuotaUsage{QuotaWindow: window, Used: used})
+		}
+		return usage
+	}
+}
+
+func TestQuotaUseCase_Execute(t *testing.T) {
+	apiKeyPrincipal := &entities.Principal{Subject: "billing", AuthType: entities.AuthTypeAPIKey, KeyID: "k1"}
+
+	tests := []struct {
+		name        string
+		policy      *entities.QuotaPolicy
+		principal   *entities.Principal
+		plan        interface{}
+		allowed     bool
+		storeErr    error
+		wantPlan    string
+		wantWindows int
+		wantErr     error
+		wantNil     bool
+	}{
+		{
+			name:        "default plan",
+			policy:      quotaPolicy(),
+			principal:   apiKeyPrincipal,
+			plan:        "",
+			allowed:     true,
+			wantPlan:    "free",
+			wantWindows: 2,
+		},
+		{
+			name:        "unknown plan falls back to the default",
+			policy:      quotaPolicy(),
+			principal:   apiKeyPrincipal,
+			plan:        "enterprise",
+			allowed:     true,
+			wantPlan:    "free",
+			wantWindows: 2,
+		},
+		{
+			name:        "exhausted",
+			policy:      quotaPolicy(),
+			principal:   apiKeyPrincipal,
+			plan:        "free",
+			allowed:     false,
+			wantPlan:    "free",
+			wantWindows: 2,
+			wantErr:     domainErrors.ErrQuotaExceeded,
+		},
+		{
+			name:      "unlimited plan",
+			policy:    quotaPolicy(),
+			principal: apiKeyPrincipal,
+			plan:      "unlimited",
+			wantNil:   true,
+		},
+		{
+			name:      "store failure allows the request",
+			policy:    quotaPolicy(),
+			principal: apiKeyPrincipal,
+			plan:      "free",
+			storeErr:  errors.New("connection refused"),
+			wantNil:   true,
+		},
+		{
+			name:      "caller without api key",
+			policy:    quotaPolicy(),
+			principal: &entities.Principal{Subject: "user-1", AuthType: entities.AuthTypeJWT},
+			wantNil:   true,
+		},
+		{
+			name:      "quotas disabled",
+			principal: apiKeyPrincipal,
+			wantNil:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockApiKeyRepository)
+			repo.On("GetKeyMetadata", mock.Anything, "k1").Return(map[string]interface{}{"id": "k1", "plan": tt.plan}, nil)
+			store := new(MockQuotaStore)
+			store.On("Consume", mock.Anything, "k1", mock.Anything).Return(usageOf(1), tt.allowed, tt.storeErr)
+			useCase := usecases.NewQuotaUseCase(tt.policy, store, repo, logger.New("test"))
+
+			decision, err := useCase.Execute(context.Background(), tt.principal)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantNil {
+				assert.NoError(t, err)
+				assert.Nil(t, decision)
+				return
+			}
+			require.NotNil(t, decision)
+			assert.Equal(t, tt.wantPlan, decision.Plan)
+			assert.Equal(t, tt.allowed, decision.Allowed)
+			assert.Len(t, decision.Usage, tt.wantWindows)
+		})
+	}
+}
+
+func TestQuotaUseCase_Execute_MetadataFailureAllows(t *testing.T) {
+	repo := new(MockApiKeyRepository)
+	repo.On("GetKeyMetadata", mock.Anything, "k1").Return(nil, errors.New("connection refused"))
+	store := new(MockQuotaStore)
+	useCase := usecases.NewQuotaUseCase(quotaPolicy(), store, repo, logger.New("test"))
+
+	decision, err := useCase.Execute(context.Background(), &entities.Principal{KeyID: "k1"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, decision)
+	store.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestQuotaUseCase_Usage(t *testing.T) {
+	repo := new(MockApiKeyRepository)
+	repo.On("GetKeyMetadata", mock.Anything, "k1").Return(map[string]interface{}{"id": "k1", "plan": "free"}, nil)
+	repo.On("GetKeyMetadata", mock.Anything, "missing").Return(nil, domainErrors.ErrApiKeyNotFound)
+	store := new(MockQuotaStore)
+	store.On("Usage", mock.Anything, "k1", mock.Anything).Return(usageOf(40), nil)
+	useCase := usecases.NewQuotaUseCase(quotaPolicy(), store, repo, logger.New("test"))
+
+	response, err := useCase.Usage(context.Background(), "k1")
+	require.NoError(t, err)
+	assert.Equal(t, "k1", response.KeyID)
+	assert.Equal(t, "free", response.Plan)
+	require.Len(t, response.Windows, 2)
+	assert.Equal(t, "daily", response.Windows[0].Period)
+	assert.Equal(t, int64(40), response.Windows[0].Used)
+	assert.Equal(t, int64(60), response.Windows[0].Remaining)
+	assert.Equal(t, "monthly", response.Windows[1].Period)
+	assert.Equal(t, int64(960), response.Windows[1].Remaining)
+
+	_, err = useCase.Usage(context.Background(), "missing")
+	assert.ErrorIs(t, err, domainErrors.ErrApiKeyNotFound)
+}
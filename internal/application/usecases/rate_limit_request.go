@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"api-gateway/internal/application/dto"
+	"api-gateway/internal/application/ports"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/logger"
+	"context"
+)
+
+// RateLimitUseCases counts requests against the rate limit of a route
+type RateLimitUseCases interface {
+	// Execute returns the decision, with ErrRateLimitExceeded when the request is rejected.
+	// Without a limit, or when the limiter fails, the request is allowed and the decision is nil.
+	Execute(ctx context.Context, req *dto.RateLimitRequest) (*entities.RateLimitDecision, error)
+}
+
+// rateLimitUseCasesImpl implements RateLimitUseCases interface
+type rateLimitUseCasesImpl struct {
+	limiter ports.RateLimiter
+	logger  logger.Logger
+}
+
+// NewRateLimitRequestUseCase creates a new instance of rate limit use cases
+func NewRateLimitRequestUseCase(limiter ports.RateLimiter, log logger.Logger) RateLimitUseCases {
+	log.Info("Initializing rate limit use case")
+
+	return &rateLimitUseCasesImpl{
+		limiter: limiter,
+		logger:  log.With("component", "rateLimit_usecases"),
+	}
+}
+
+func (r rateLimitUseCasesImpl) Execute(ctx context.Context, req *dto.RateLimitRequest) (*entities.RateLimitDecision, error) {
+	if req.Limit == nil {
+		return nil, nil
+	}
+
+	key := rateLimitKey(req)
+	decision, err := r.limiter.Allow(ctx, key, req.Limit)
+	if err != nil {
+		// Losing the limiter must not take the whole gateway down with it
+		r.logger.Error("Rate limiter failed, allowing request",
+			"route_id", req.RouteID,
+			"limit", req.Limit.Name,
+			"error", err,
+		)
+		return nil, nil
+	}
+
+	if !decision.Allowed {
+		r.logger.Warn("Rate limit exceeded",
+			"route_id", req.RouteID,
+			"limit", req.Limit.Name,
+			"key", key,
+			"retry_after_ms", decision.RetryAfter.Milliseconds(),
+		)
+		return decision, domainErrors.ErrRateLimitExceeded
+	}
+
+	r.logger.Debug("Rate limit passed",
+		"route_id", req.RouteID,
+		"limit", req.Limit.Name,
+		"key", key,
+		"remaining", decision.Remaining,
+	)
+	return decision, nil
+}
+
+// rateLimitKey names the bucket of a request, scoped to the limit so that
+// different limits never share tokens
+func rateLimitKey(req *dto.RateLimitRequest) string {
+	scope := req.Limit.Name + "|"
+	switch req.Limit.KeyBy {
+	case entities.RateLimitKeyRoute:
+		return scope + "route"
+	case entities.RateLimitKeyIP:
+		return scope + "ip:" + req.ClientIP
+	}
+
+	if principal := req.Principal; principal != nil {
+		if principal.KeyID != "" {
+			return scope + "key:" + principal.KeyID
+		}
+		if principal.Subject != "" {
+			return scope + "user:" + principal.AuthType + ":" + principal.Subject
+		}
+	}
+	return scope + "ip:" + req.ClientIP
+}
@@ -0,0 +1,130 @@
+package usecases_test
+
+import (
+	"api-gateway/pkg/logger"
+	"context"
+	"errors"
+	"testing"
+
+	"api-gateway/internal/application/dto"
+	"api-gateway/internal/application/usecases"
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRateLimiter is a mock for the RateLimiter port
+type MockRateLimiter struct {
+	mock.Mock
+}
+
+func (m *MockRateLimiter) Allow(ctx context.Context, key string, limit *entities.RateLimit) (*entities.RateLimitDecision, error) {
+	args := m.Called(ctx, key, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RateLimitDecision), args.Error(1)
+}
+
+func TestRateLimitRequestUseCase_Execute_Keys(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyBy     string
+		principal *entities.Principal
+		wantKey   string
+	}{
+		{
+			name:      "consumer with api key",
+			keyBy:     entities.RateLimitKeyConsumer,
+			principal: &entities.Principal{Subject: "billing", AuthType: entities.AuthTypeAPIKey, KeyID: "k1"},
+			wantKey:   "orders-list|key:k1",
+		},
+		{
+			name:      "consumer with user",
+			keyBy:     "",
+			principal: &entities.Principal{Subject: "user-1", AuthType: entities.AuthTypeJWT},
+			wantKey:   "orders-list|user:jwt:user-1",
+		},
+		{
+			name:    "anonymous consumer falls back to ip",
+			keyBy:   entities.RateLimitKeyConsumer,
+			wantKey: "orders-list|ip:10.0.0.1",
+		},
+		{
+			name:      "ip",
+			keyBy:     entities.RateLimitKeyIP,
+			principal: &entities.Principal{Subject: "billing", KeyID: "k1"},
+			wantKey:   "orders-list|ip:10.0.0.1",
+		},
+		{
+			name:      "route",
+			keyBy:     entities.RateLimitKeyRoute,
+			principal: &entities.Principal{Subject: "billing", KeyID: "k1"},
+			wantKey:   "orders-list|route",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := new(MockRateLimiter)
+			limit := &entities.RateLimit{Name: "orders-list", Rate: 10, Burst: 10, KeyBy: tt.keyBy}
+			decision := &entities.RateLimitDecision{Allowed: true, Limit: 10, Remaining: 9}
+			limiter.On("Allow", mock.Anything, tt.wantKey, limit).Return(decision, nil)
+			useCase := usecases.NewRateLimitRequestUseCase(limiter, logger.New("test"))
+
+			got, err := useCase.Execute(context.Background(), &dto.RateLimitRequest{
+				RouteID:   "orders-list",
+				Limit:     limit,
+				Principal: tt.principal,
+				ClientIP:  "10.0.0.1",
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, decision, got)
+			limiter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRateLimitRequestUseCase_Execute_Exceeded(t *testing.T) {
+	limiter := new(MockRateLimiter)
+	decision := &entities.RateLimitDecision{Allowed: false, Limit: 10}
+	limiter.On("Allow", mock.Anything, mock.Anything, mock.Anything).Return(decision, nil)
+	useCase := usecases.NewRateLimitRequestUseCase(limiter, logger.New("test"))
+
+	got, err := useCase.Execute(context.Background(), &dto.RateLimitRequest{
+		RouteID:  "orders-list",
+		Limit:    &entities.RateLimit{Name: "orders-list", Rate: 10, Burst: 10},
+		ClientIP: "10.0.0.1",
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrRateLimitExceeded)
+	assert.Equal(t, decision, got)
+}
+
+func TestRateLimitRequestUseCase_Execute_LimiterFailureAllows(t *testing.T) {
+	limiter := new(MockRateLimiter)
+	limiter.On("Allow", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+	useCase := usecases.NewRateLimitRequestUseCase(limiter, logger.New("test"))
+
+	got, err := useCase.Execute(context.Background(), &dto.RateLimitRequest{
+		RouteID: "orders-list",
+		Limit:   &entities.RateLimit{Name: "orders-list", Rate: 10, Burst: 10},
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestRateLimitRequestUseCase_Execute_NoLimit(t *testing.T) {
+	limiter := new(MockRateLimiter)
+	useCase := usecases.NewRateLimitRequestUseCase(limiter, logger.New("test"))
+
+	got, err := useCase.Execute(context.Background(), &dto.RateLimitRequest{RouteID: "orders-list"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+	limiter.AssertNotCalled(t, "Allow")
+}
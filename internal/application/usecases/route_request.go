@@ -6,6 +6,8 @@ import (
 	"api-gateway/internal/domain/entities"
 	"api-gateway/pkg/logger"
 	"context"
+	"errors"
+	"net/http"
 	"strings"
 	"time"
 )
@@ -22,10 +24,12 @@ type routeRequestUseCaseImpl struct {
 	logger           logger.Logger
 	routeRepo        ports.RouteRepository
 	proxyClient      ports.ProxyClient
+	limiter          ports.ConcurrencyLimiter
+	balancer         ports.LoadBalancer
 }
 
 // NewRouteRequestUseCase creates a new instance of route request use case
-func NewRouteRequestUseCase(serverPathPrefix string, proxyClient ports.ProxyClient, routeRepo ports.RouteRepository, log logger.Logger) RouteRequestUseCases {
+func NewRouteRequestUseCase(serverPathPrefix string, proxyClient ports.ProxyClient, routeRepo ports.RouteRepository, limiter ports.ConcurrencyLimiter, balancer ports.LoadBalancer, log logger.Logger) RouteRequestUseCases {
 	log.Info("Initializing route request use case",
 		"server_path_prefix", serverPathPrefix,
 	)
@@ -34,6 +38,8 @@ func NewRouteRequestUseCase(serverPathPrefix string, proxyClient ports.ProxyClie
 		serverPathPrefix: serverPathPrefix,
 		routeRepo:        routeRepo,
 		proxyClient:      proxyClient,
+		limiter:          limiter,
+		balancer:         balancer,
 		logger:           log.With("component", "routeRequest_usecases"),
 	}
 }
@@ -107,6 +113,27 @@ func (r routeRequestUseCaseImpl) Execute(ctx context.Context, req *dto.GatewayRe
 		URL:     req.Host + req.Path,
 	}
 
+	release, err := r.acquire(ctx, req.Backend)
+	if err != nil {
+		return nil, err
+	}
+
+	// Targets are picked once a slot is free, so connection counts are current
+	releaseTarget := func(entities.TargetOutcome) {}
+	if req.Backend != nil && len(req.Backend.Targets) > 0 {
+		target, releasePicked, err := r.balancer.Pick(req.Backend, balancingKey(req))
+		if err != nil {
+			release(entities.ConcurrencyOutcomeIgnored, 0)
+			r.logger.Error("No backend target available",
+				"backend_id", req.Backend.Id,
+				"error", err,
+			)
+			return nil, err
+		}
+		releaseTarget = releasePicked
+		proxyRequest.URL = req.Backend.TargetURL(target, req.Path)
+	}
+
 	r.logger.Info("Forwarding request to backend via proxy",
 		"url", proxyRequest.URL,
 		"method", proxyRequest.Method,
@@ -115,6 +142,8 @@ func (r routeRequestUseCaseImpl) Execute(ctx context.Context, req *dto.GatewayRe
 	proxyStart := time.Now()
 	res, err := r.proxyClient.Forward(ctx, &proxyRequest)
 	proxyDuration := time.Since(proxyStart)
+	release(concurrencyOutcome(res, err), proxyDuration)
+	releaseTarget(targetOutcome(res, err))
 
 	if err != nil {
 		r.logger.Error("Proxy forward failed",
@@ -157,3 +186,76 @@ func (r routeRequestUseCaseImpl) Execute(ctx context.Context, req *dto.GatewayRe
 
 	return &gatewayResponse, nil
 }
+
+// acquire takes a slot of the backend's concurrency limit, shedding the
+// request with ErrBackendOverloaded when the backend is at capacity
+func (r routeRequestUseCaseImpl) acquire(ctx context.Context, backend *entities.Backend) (ports.ConcurrencyRelease, error) {
+	if backend == nil || backend.ConcurrencyLimit == nil {
+		return func(entities.ConcurrencyOutcome, time.Duration) {}, nil
+	}
+
+	waitStart := time.Now()
+	release, err := r.limiter.Acquire(ctx, backend.Id, backend.ConcurrencyLimit)
+	if err != nil {
+		r.logger.Warn("Backend at capacity, shedding request",
+			"backend_id", backend.Id,
+			"max_requests", backend.ConcurrencyLimit.MaxRequests,
+			"max_queue", backend.ConcurrencyLimit.MaxQueue,
+			"wait_ms", time.Since(waitStart).Milliseconds(),
+			"error", err,
+		)
+		return nil, err
+	}
+	return release, nil
+}
+
+// concurrencyOutcome classifies a forwarded request for adaptive limits.
+// Transport failures and responses asking to back off mean the backend is
+// overloaded, other responses say nothing bad about its load.
+func concurrencyOutcome(res *dto.ProxyResponse, err error) entities.ConcurrencyOutcome {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return entities.ConcurrencyOutcomeIgnored
+		}
+		return entities.ConcurrencyOutcomeDropped
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return entities.ConcurrencyOutcomeDropped
+	}
+	return entities.ConcurrencyOutcomeSuccess
+}
+
+// targetOutcome classifies a forwarded request for outlier detection. Server
+// errors, failed connections and timeouts count against the target.
+func targetOutcome(res *dto.ProxyResponse, err error) entities.TargetOutcome {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return entities.TargetOutcomeIgnored
+		}
+		return entities.TargetOutcomeFailure
+	}
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return entities.TargetOutcomeFailure
+	}
+	return entities.TargetOutcomeSuccess
+}
+
+// balancingKey is the request value consistent hashing routes by
+func balancingKey(req *dto.GatewayRequest) string {
+	balancing := req.Backend.LoadBalancing
+	if balancing.Strategy != entities.BalancerConsistentHash {
+		return ""
+	}
+
+	if balancing.HashHeader != "" {
+		return http.Header(req.Headers).Get(balancing.HashHeader)
+	}
+	cookie, err := (&http.Request{Header: req.Headers}).Cookie(balancing.HashCookie)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
@@ -6,10 +6,13 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"api-gateway/internal/application/dto"
+	"api-gateway/internal/application/ports"
 	"api-gateway/internal/application/usecases"
 	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -51,6 +54,38 @@ func (m *MockProxyClient) Forward(ctx context.Context, req *dto.ProxyRequest) (*
 	return args.Get(0).(*dto.ProxyResponse), args.Error(1)
 }
 
+// MockConcurrencyLimiter is a mock for the ConcurrencyLimiter port
+type MockConcurrencyLimiter struct {
+	mock.Mock
+	outcomes []entities.ConcurrencyOutcome
+}
+
+func (m *MockConcurrencyLimiter) Acquire(ctx context.Context, key string, limit *entities.ConcurrencyLimit) (ports.ConcurrencyRelease, error) {
+	args := m.Called(ctx, key, limit)
+	if args.Error(0) != nil {
+		return nil, args.Error(0)
+	}
+	return func(outcome entities.ConcurrencyOutcome, latency time.Duration) {
+		m.outcomes = append(m.outcomes, outcome)
+	}, nil
+}
+
+// MockLoadBalancer is a mock for the LoadBalancer port
+type MockLoadBalancer struct {
+	mock.Mock
+	outcomes []entities.TargetOutcome
+}
+
+func (m *MockLoadBalancer) Pick(backend *entities.Backend, hashKey string) (*entities.Target, ports.TargetRelease, error) {
+	args := m.Called(backend, hashKey)
+	if args.Error(1) != nil {
+		return nil, nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Target), func(outcome entities.TargetOutcome) {
+		m.outcomes = append(m.outcomes, outcome)
+	}, nil
+}
+
 func TestRouteRequestUseCase_Execute_Success(t *testing.T) {
 	mockRepo := new(MockRouteRepository)
 	mockProxy := new(MockProxyClient)
@@ -80,7 +115,7 @@ func TestRouteRequestUseCase_Execute_Success(t *testing.T) {
 	mockProxy.On("Forward", mock.Anything, expectedProxyReq).Return(proxyResponse, nil)
 
 	// Fixed: Added serverPathPrefix parameter and correct order
-	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, mockRepo, log)
+	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, mockRepo, new(MockConcurrencyLimiter), new(MockLoadBalancer), log)
 
 	response, err := useCase.Execute(context.Background(), request)
 
@@ -107,7 +142,7 @@ func TestRouteRequestUseCase_Execute_ProxyError(t *testing.T) {
 		Return(nil, errors.New("connection timeout"))
 
 	// Fixed: Added serverPathPrefix parameter and correct order
-	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, mockRepo, log)
+	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, mockRepo, new(MockConcurrencyLimiter), new(MockLoadBalancer), log)
 
 	response, err := useCase.Execute(context.Background(), request)
 
@@ -147,7 +182,7 @@ func TestRouteRequestUseCase_GetRoute_Success(t *testing.T) {
 	mockRepo.On("FindByPathAndMethod", mock.Anything, "/user/users", "GET").
 		Return(expectedRoute, nil)
 
-	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, mockRepo, log)
+	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, mockRepo, new(MockConcurrencyLimiter), new(MockLoadBalancer), log)
 
 	route, err := useCase.GetRoute(context.Background(), request)
 
@@ -172,7 +207,7 @@ func TestRouteRequestUseCase_GetRoute_NotFound(t *testing.T) {
 	mockRepo.On("FindByPathAndMethod", mock.Anything, "/user/notfound", "GET").
 		Return(nil, errors.New("route not found"))
 
-	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, mockRepo, log)
+	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, mockRepo, new(MockConcurrencyLimiter), new(MockLoadBalancer), log)
 
 	route, err := useCase.GetRoute(context.Background(), request)
 
@@ -182,3 +217,194 @@ func TestRouteRequestUseCase_GetRoute_NotFound(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestRouteRequestUseCase_Execute_ConcurrencyLimit(t *testing.T) {
+	limit := &entities.ConcurrencyLimit{Algorithm: entities.ConcurrencyAlgorithmAIMD, MaxRequests: 10, MinRequests: 1}
+	backend := &entities.Backend{Id: "orders", Host: "http://service:8080", ConcurrencyLimit: limit}
+
+	tests := []struct {
+		name        string
+		response    *dto.ProxyResponse
+		proxyErr    error
+		wantOutcome entities.ConcurrencyOutcome
+	}{
+		{
+			name:        "success",
+			response:    &dto.ProxyResponse{StatusCode: http.StatusNotFound},
+			wantOutcome: entities.ConcurrencyOutcomeSuccess,
+		},
+		{
+			name:        "backend asks to back off",
+			response:    &dto.ProxyResponse{StatusCode: http.StatusServiceUnavailable},
+			wantOutcome: entities.ConcurrencyOutcomeDropped,
+		},
+		{
+			name:        "transport failure",
+			proxyErr:    errors.New("connection timeout"),
+			wantOutcome: entities.ConcurrencyOutcomeDropped,
+		},
+		{
+			name:        "canceled request",
+			proxyErr:    context.Canceled,
+			wantOutcome: entities.ConcurrencyOutcomeIgnored,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProxy := new(MockProxyClient)
+			if tt.proxyErr != nil {
+				mockProxy.On("Forward", mock.Anything, mock.Anything).Return(nil, tt.proxyErr)
+			} else {
+				mockProxy.On("Forward", mock.Anything, mock.Anything).Return(tt.response, nil)
+			}
+			limiter := new(MockConcurrencyLimiter)
+			limiter.On("Acquire", mock.Anything, "orders", limit).Return(nil)
+			useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, new(MockRouteRepository), limiter, new(MockLoadBalancer), logger.New("test"))
+
+			_, _ = useCase.Execute(context.Background(), &dto.GatewayRequest{Path: "/orders", Method: "GET", Host: backend.Host, Backend: backend})
+
+			assert.Equal(t, []entities.ConcurrencyOutcome{tt.wantOutcome}, limiter.outcomes)
+		})
+	}
+}
+
+func TestRouteRequestUseCase_Execute_Shed(t *testing.T) {
+	mockProxy := new(MockProxyClient)
+	limiter := new(MockConcurrencyLimiter)
+	limiter.On("Acquire", mock.Anything, "orders", mock.Anything).Return(domainErrors.ErrBackendOverloaded)
+	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, new(MockRouteRepository), limiter, new(MockLoadBalancer), logger.New("test"))
+
+	backend := &entities.Backend{Id: "orders", Host: "http://service:8080", ConcurrencyLimit: &entities.ConcurrencyLimit{MaxRequests: 1}}
+	response, err := useCase.Execute(context.Background(), &dto.GatewayRequest{Path: "/orders", Method: "GET", Host: backend.Host, Backend: backend})
+
+	assert.ErrorIs(t, err, domainErrors.ErrBackendOverloaded)
+	assert.Nil(t, response)
+	mockProxy.AssertNotCalled(t, "Forward", mock.Anything, mock.Anything)
+}
+
+func TestRouteRequestUseCase_Execute_LoadBalancing(t *testing.T) {
+	tests := []struct {
+		name      string
+		balancing entities.LoadBalancing
+		headers   map[string][]string
+		wantKey   string
+	}{
+		{
+			name:      "round robin has no key",
+			balancing: entities.LoadBalancing{Strategy: entities.BalancerRoundRobin},
+			headers:   map[string][]string{"X-User-Id": {"42"}},
+		},
+		{
+			name:      "hash by header",
+			balancing: entities.LoadBalancing{Strategy: entities.BalancerConsistentHash, HashHeader: "X-User-ID"},
+			headers:   map[string][]string{"X-User-Id": {"42"}},
+			wantKey:   "42",
+		},
+		{
+			name:      "hash by cookie",
+			balancing: entities.LoadBalancing{Strategy: entities.BalancerConsistentHash, HashCookie: "session"},
+			headers:   map[string][]string{"Cookie": {"theme=dark; session=abc"}},
+			wantKey:   "abc",
+		},
+		{
+			name:      "missing cookie",
+			balancing: entities.LoadBalancing{Strategy: entities.BalancerConsistentHash, HashCookie: "session"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &entities.Backend{
+				Id:            "orders",
+				Host:          "http://orders-1:8080",
+				PathPrefix:    "/v1",
+				Targets:       []entities.Target{{Host: "http://orders-1:8080", Weight: 1}, {Host: "http://orders-2:8080", Weight: 1}},
+				LoadBalancing: tt.balancing,
+			}
+			mockProxy := new(MockProxyClient)
+			mockProxy.On("Forward", mock.Anything, mock.MatchedBy(func(req *dto.ProxyRequest) bool {
+				return req.URL == "http://orders-2:8080/v1/orders"
+			})).Return(&dto.ProxyResponse{StatusCode: http.StatusOK}, nil)
+			balancer := new(MockLoadBalancer)
+			balancer.On("Pick", backend, tt.wantKey).Return(&entities.Target{Host: "http://orders-2:8080", Weight: 1}, nil)
+			useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, new(MockRouteRepository), new(MockConcurrencyLimiter), balancer, logger.New("test"))
+
+			response, err := useCase.Execute(context.Background(), &dto.GatewayRequest{Path: "/orders", Method: "GET", Host: backend.Host, Headers: tt.headers, Backend: backend})
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, []entities.TargetOutcome{entities.TargetOutcomeSuccess}, balancer.outcomes, "the target is handed back once the request finished")
+			mockProxy.AssertExpectations(t)
+			balancer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRouteRequestUseCase_Execute_NoTarget(t *testing.T) {
+	backend := &entities.Backend{Id: "orders", Host: "http://orders-1:8080", Targets: []entities.Target{{Host: "http://orders-1:8080", Weight: 1}}}
+	mockProxy := new(MockProxyClient)
+	balancer := new(MockLoadBalancer)
+	balancer.On("Pick", backend, "").Return(nil, domainErrors.ErrBackendUnavailable)
+	useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, new(MockRouteRepository), new(MockConcurrencyLimiter), balancer, logger.New("test"))
+
+	response, err := useCase.Execute(context.Background(), &dto.GatewayRequest{Path: "/orders", Method: "GET", Host: backend.Host, Backend: backend})
+
+	assert.ErrorIs(t, err, domainErrors.ErrBackendUnavailable)
+	assert.Nil(t, response)
+	mockProxy.AssertNotCalled(t, "Forward", mock.Anything, mock.Anything)
+}
+
+func TestRouteRequestUseCase_Execute_TargetOutcome(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    *dto.ProxyResponse
+		proxyErr    error
+		wantOutcome entities.TargetOutcome
+	}{
+		{
+			name:        "client error",
+			response:    &dto.ProxyResponse{StatusCode: http.StatusNotFound},
+			wantOutcome: entities.TargetOutcomeSuccess,
+		},
+		{
+			name:        "rate limited",
+			response:    &dto.ProxyResponse{StatusCode: http.StatusTooManyRequests},
+			wantOutcome: entities.TargetOutcomeSuccess,
+		},
+		{
+			name:        "server error",
+			response:    &dto.ProxyResponse{StatusCode: http.StatusBadGateway},
+			wantOutcome: entities.TargetOutcomeFailure,
+		},
+		{
+			name:        "connection failure",
+			proxyErr:    errors.New("connection refused"),
+			wantOutcome: entities.TargetOutcomeFailure,
+		},
+		{
+			name:        "canceled request",
+			proxyErr:    context.Canceled,
+			wantOutcome: entities.TargetOutcomeIgnored,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &entities.Backend{Id: "orders", Host: "http://orders-1:8080", Targets: []entities.Target{{Host: "http://orders-1:8080", Weight: 1}}}
+			mockProxy := new(MockProxyClient)
+			if tt.proxyErr != nil {
+				mockProxy.On("Forward", mock.Anything, mock.Anything).Return(nil, tt.proxyErr)
+			} else {
+				mockProxy.On("Forward", mock.Anything, mock.Anything).Return(tt.response, nil)
+			}
+			balancer := new(MockLoadBalancer)
+			balancer.On("Pick", backend, "").Return(&backend.Targets[0], nil)
+			useCase := usecases.NewRouteRequestUseCase("/api", mockProxy, new(MockRouteRepository), new(MockConcurrencyLimiter), balancer, logger.New("test"))
+
+			_, _ = useCase.Execute(context.Background(), &dto.GatewayRequest{Path: "/orders", Method: "GET", Host: backend.Host, Backend: backend})
+
+			assert.Equal(t, []entities.TargetOutcome{tt.wantOutcome}, balancer.outcomes)
+		})
+	}
+}
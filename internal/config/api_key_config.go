@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// API key stores selectable with api_keys.store
+const (
+	ApiKeyStoreRedis  = "redis"
+	ApiKeyStoreMemory = "memory"
+	ApiKeyStoreFile   = "file"
+)
+
+// ApiKeysConfig selects where API keys are stored and how they are
+// bootstrapped. Seeds are only written when a key with the same id was never
+// stored or revoked before.
+type ApiKeysConfig struct {
+	Store    string             `mapstructure:"store"`
+	File     string             `mapstructure:"file"`
+	Seed     []ApiKeySeedConfig `mapstructure:"seed"`
+	SeedFile string             `mapstructure:"seed_file"`
+	Cache    ApiKeyCacheConfig  `mapstructure:"cache"`
+}
+
+// ApiKeyCacheConfig bounds the in-process cache in front of the Redis key
+// store. Changes are propagated between instances over Redis pub/sub, the
+// TTLs bound staleness when a change notification is missed.
+type ApiKeyCacheConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Size        int           `mapstructure:"size"`
+	TTL         time.Duration `mapstructure:"ttl"`
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"`
+}
+
+// ApiKeySeedConfig is a pre-hashed key, e.g. the output of "api-gateway apikey hash"
+type ApiKeySeedConfig struct {
+	ID         string   `mapstructure:"id"`
+	Salt       string   `mapstructure:"salt"`
+	SecretHash string   `mapstructure:"secret_hash"`
+	Owner      string   `mapstructure:"owner"`
+	Scopes     []string `mapstructure:"scopes"`
+	Roles      []string `mapstructure:"roles"`
+	Plan       string   `mapstructure:"plan"`
+	ExpiresAt  string   `mapstructure:"expires_at"`
+	Disabled   bool     `mapstructure:"disabled"`
+}
+
+// LoadApiKeySeeds reads the keys listed under "keys" in a YAML or JSON seed file
+func LoadApiKeySeeds(path string) ([]ApiKeySeedConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read api key seed file: %w", err)
+	}
+
+	var seeds struct {
+		Keys []ApiKeySeedConfig `mapstructure:"keys"`
+	}
+	if err := v.Unmarshal(&seeds); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal api key seed file: %w", err)
+	}
+	return seeds.Keys, nil
+}
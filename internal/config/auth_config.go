@@ -0,0 +1,95 @@
+package config
+
+import "time"
+
+// JWTConfig can be declared on a route's auth_policy or on a backend, in which
+// case it applies to every jwt route of that backend without its own keys
+type JWTConfig struct {
+	Issuer    string         `mapstructure:"issuer"`
+	Audiences []string       `mapstructure:"audiences"`
+	ClockSkew time.Duration  `mapstructure:"clock_skew"`
+	Keys      []JWTKeyConfig `mapstructure:"keys"`
+	JWKS      *JWKSConfig    `mapstructure:"jwks"`
+}
+
+type JWKSConfig struct {
+	URL             string        `mapstructure:"url"`
+	File            string        `mapstructure:"file"`
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+	CacheTTL        time.Duration `mapstructure:"cache_ttl"`
+}
+
+type JWTKeyConfig struct {
+	ID            string `mapstructure:"id"`
+	Algorithm     string `mapstructure:"algorithm"`
+	Secret        string `mapstructure:"secret"`
+	PublicKey     string `mapstructure:"public_key"`
+	PublicKeyFile string `mapstructure:"public_key_file"`
+}
+
+// HMACConfig lists the shared secrets clients sign requests with. Secrets
+// can be read from a file to keep them out of the config file.
+type HMACConfig struct {
+	Credentials   []HMACCredentialConfig `mapstructure:"credentials"`
+	SignedHeaders []string               `mapstructure:"signed_headers"`
+	MaxClockSkew  time.Duration          `mapstructure:"max_clock_skew"`
+}
+
+type HMACCredentialConfig struct {
+	ID         string   `mapstructure:"id"`
+	Secret     string   `mapstructure:"secret"`
+	SecretFile string   `mapstructure:"secret_file"`
+	Owner      string   `mapstructure:"owner"`
+	Scopes     []string `mapstructure:"scopes"`
+	Roles      []string `mapstructure:"roles"`
+}
+
+// MTLSConfig restricts which client certificates a route accepts. Without
+// ca_file the certificate must have been verified by the listener against
+// server.tls.client_ca_file. Empty allow lists accept any verified certificate.
+type MTLSConfig struct {
+	CAFile   string   `mapstructure:"ca_file"`
+	Subjects []string `mapstructure:"subjects"`
+	DNSNames []string `mapstructure:"dns_names"`
+	URIs     []string `mapstructure:"uris"`
+	Emails   []string `mapstructure:"emails"`
+}
+
+// BasicAuthConfig reads credentials from an htpasswd file with bcrypt or
+// argon2 hashes, or with source api_keys from the API key store using the key
+// id as user name and the secret as password
+type BasicAuthConfig struct {
+	Realm        string `mapstructure:"realm"`
+	Source       string `mapstructure:"source"`
+	HtpasswdFile string `mapstructure:"htpasswd_file"`
+}
+
+// ExternalAuthConfig delegates the decision to allow a request to an HTTP
+// authorization service. Decisions are cached for cache_ttl when set, which
+// requires forward_headers.
+type ExternalAuthConfig struct {
+	Endpoint       string        `mapstructure:"endpoint"`
+	Timeout        time.Duration `mapstructure:"timeout"`
+	CacheTTL       time.Duration `mapstructure:"cache_ttl"`
+	FailOpen       bool          `mapstructure:"fail_open"`
+	ForwardHeaders []string      `mapstructure:"forward_headers"`
+}
+
+type IntrospectionConfig struct {
+	Endpoint         string        `mapstructure:"endpoint"`
+	ClientID         string        `mapstructure:"client_id"`
+	ClientSecret     string        `mapstructure:"client_secret"`
+	RequiredScopes   []string      `mapstructure:"required_scopes"`
+	CacheTTL         time.Duration `mapstructure:"cache_ttl"`
+	NegativeCacheTTL time.Duration `mapstructure:"negative_cache_ttl"`
+	Timeout          time.Duration `mapstructure:"timeout"`
+}
+
+// AuthorizationConfig lists the scopes and roles required on a route, matched
+// with all_of (default) or any_of, and an optional rule expression that has to hold
+type AuthorizationConfig struct {
+	RequiredScopes []string `mapstructure:"required_scopes"`
+	RequiredRoles  []string `mapstructure:"required_roles"`
+	Match          string   `mapstructure:"match"`
+	Rule           string   `mapstructure:"rule"`
+}
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// ConcurrencyConfig caps the requests in flight to a backend, requests beyond
+// max_requests wait in a queue and are shed with a 503 when it is full
+type ConcurrencyConfig struct {
+	MaxRequests int `mapstructure:"max_requests"`
+	// MaxQueue is how many requests may wait for a slot, zero sheds right away
+	MaxQueue     int           `mapstructure:"max_queue"`
+	QueueTimeout time.Duration `mapstructure:"queue_timeout"`
+	// Algorithm is fixed, aimd or gradient; adaptive limits start at max_requests
+	// and move between min_requests and max_requests
+	Algorithm        string        `mapstructure:"algorithm"`
+	MinRequests      int           `mapstructure:"min_requests"`
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+}
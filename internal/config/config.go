@@ -18,6 +18,10 @@ type Config struct {
 	Security    SecurityConfig         `mapstructure:"security"`
 	Logging     LoggingConfig          `mapstructure:"logging"`
 	Redis       RedisConfig            `mapstructure:"redis"`
+	Admin       AdminConfig            `mapstructure:"admin"`
+	ApiKeys     ApiKeysConfig          `mapstructure:"api_keys"`
+	Identity    IdentityConfig         `mapstructure:"identity"`
+	Quotas      QuotasConfig           `mapstructure:"quotas"`
 	Backends    []BackendServiceConfig `mapstructure:"backends"`
 }
 
@@ -41,6 +45,7 @@ type ServerConfig struct {
 	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 	CORS            CORSConfig    `mapstructure:"cors"`
+	TLS             TLSConfig     `mapstructure:"tls"`
 }
 
 type CORSConfig struct {
@@ -49,29 +54,66 @@ type CORSConfig struct {
 	AllowHeaders []string `mapstructure:"allow_headers"`
 }
 
+// SecurityConfig holds the default rate limit of every route, which routes
+// can override with their own rate_limit section. A rate_limit_rps of 0 turns
+// the default off.
 type SecurityConfig struct {
 	RateLimitRPS   int `mapstructure:"rate_limit_rps"`
 	RateLimitBurst int `mapstructure:"rate_limit_burst"`
+	// RateLimitKeyBy is consumer, ip or route
+	RateLimitKeyBy string `mapstructure:"rate_limit_key_by"`
+	// TrustForwardedFor takes the client IP from X-Forwarded-For. Only enable it
+	// behind a proxy that sets the header, clients can forge it otherwise.
+	TrustForwardedFor bool `mapstructure:"trust_forwarded_for"`
 }
 
-type AuthPolicy struct {
-	Type    string `mapstructure:"type"`
+// AdminConfig enables the /admin API, guarded by a bearer token. Prefer
+// setting the token through API_GATEWAY_ADMIN_TOKEN over the config file.
+type AdminConfig struct {
 	Enabled bool   `mapstructure:"enabled"`
+	Token   string `mapstructure:"token"`
+}
+
+type AuthPolicy struct {
+	Type          string               `mapstructure:"type"`
+	Enabled       bool                 `mapstructure:"enabled"`
+	JWT           *JWTConfig           `mapstructure:"jwt"`
+	Introspection *IntrospectionConfig `mapstructure:"introspection"`
+	HMAC          *HMACConfig          `mapstructure:"hmac"`
+	MTLS          *MTLSConfig          `mapstructure:"mtls"`
+	Basic         *BasicAuthConfig     `mapstructure:"basic"`
+	External      *ExternalAuthConfig  `mapstructure:"external"`
+	// Match and Policies configure a composite policy; the listed policies
+	// inherit enabled from it
+	Match    string       `mapstructure:"match"`
+	Policies []AuthPolicy `mapstructure:"policies"`
 }
 type RouteConfig struct {
-	ID         string      `mapstructure:"id"`
-	Method     string      `mapstructure:"method"`
-	Path       string      `mapstructure:"path"`
-	PathType   string      `mapstructure:"path_type,omitempty"`
-	Enabled    bool        `mapstructure:"enabled"`
-	AuthPolicy *AuthPolicy `mapstructure:"auth_policy"`
+	ID            string               `mapstructure:"id"`
+	Method        string               `mapstructure:"method"`
+	Path          string               `mapstructure:"path"`
+	PathType      string               `mapstructure:"path_type,omitempty"`
+	Enabled       bool                 `mapstructure:"enabled"`
+	AuthPolicy    *AuthPolicy          `mapstructure:"auth_policy"`
+	Authorization *AuthorizationConfig `mapstructure:"authorization"`
+	RateLimit     *RateLimitConfig     `mapstructure:"rate_limit"`
 }
 
 type BackendServiceConfig struct {
-	Host       string        `mapstructure:"host"`
-	ID         string        `mapstructure:"id"`
-	PathPrefix string        `mapstructure:"path_prefix, omitempty"`
-	Routes     []RouteConfig `mapstructure:"routes"`
+	Host       string     `mapstructure:"host"`
+	ID         string     `mapstructure:"id"`
+	PathPrefix string     `mapstructure:"path_prefix, omitempty"`
+	JWT        *JWTConfig `mapstructure:"jwt"`
+	// Targets replace host to balance requests over several upstream hosts
+	Targets      []TargetConfig     `mapstructure:"targets"`
+	LoadBalancer LoadBalancerConfig `mapstructure:"load_balancer"`
+	// HealthCheck probes the targets, all of them stay in rotation when unset
+	HealthCheck *HealthCheckConfig `mapstructure:"health_check"`
+	// OutlierDetection ejects targets failing live traffic, none are ejected when unset
+	OutlierDetection *OutlierDetectionConfig `mapstructure:"outlier_detection"`
+	// Concurrency limits the requests in flight to the backend, unlimited when unset
+	Concurrency *ConcurrencyConfig `mapstructure:"concurrency"`
+	Routes      []RouteConfig      `mapstructure:"routes"`
 }
 
 func Load(configFile, env string) (*Config, error) {
@@ -130,12 +172,45 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.cors.allow_origins", []string{"*"})
 	v.SetDefault("server.cors.allow_methods", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
 	v.SetDefault("server.cors.allow_headers", []string{"*"})
+	v.SetDefault("server.tls.enabled", false)
+	v.SetDefault("server.tls.cert_file", "")
+	v.SetDefault("server.tls.key_file", "")
+	v.SetDefault("server.tls.client_ca_file", "")
+	v.SetDefault("server.tls.min_version", "1.2")
+	v.SetDefault("server.tls.client_auth", ClientAuthNone)
 	v.SetDefault("backends", []string{})
 
 	DatabaseDefaults(v)
 
 	v.SetDefault("security.rate_limit_rps", 100)
 	v.SetDefault("security.rate_limit_burst", 200)
+	v.SetDefault("security.rate_limit_key_by", "consumer")
+	v.SetDefault("security.trust_forwarded_for", false)
+
+	v.SetDefault("admin.enabled", false)
+	v.SetDefault("admin.token", "")
+	v.SetDefault("api_keys.store", ApiKeyStoreRedis)
+	v.SetDefault("api_keys.file", "")
+	v.SetDefault("api_keys.seed_file", "")
+	v.SetDefault("api_keys.cache.enabled", true)
+	v.SetDefault("api_keys.cache.size", 10000)
+	v.SetDefault("api_keys.cache.ttl", 15*time.Second)
+	v.SetDefault("api_keys.cache.negative_ttl", 5*time.Second)
+
+	v.SetDefault("identity.headers", true)
+	v.SetDefault("identity.strip_credentials", true)
+	v.SetDefault("identity.token.enabled", false)
+	v.SetDefault("identity.token.header", "X-Gateway-Identity")
+	v.SetDefault("identity.token.issuer", "api-gateway")
+	v.SetDefault("identity.token.ttl", time.Minute)
+	v.SetDefault("identity.token.algorithm", "HS256")
+	v.SetDefault("identity.token.key_id", "")
+	v.SetDefault("identity.token.secret", "")
+	v.SetDefault("identity.token.private_key_file", "")
+
+	v.SetDefault("quotas.enabled", false)
+	v.SetDefault("quotas.default_plan", "")
+	v.SetDefault("quotas.timezone", "UTC")
 
 	DefaultLogger(v)
 }
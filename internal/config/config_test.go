@@ -0,0 +1,36 @@
+package config_test
+
+import (
+	"api-gateway/internal/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoad_ProbeRoutesSkipRateLimit keeps health checks and metrics scrapes of
+// the shipped configs out of the default rate limit, so they never get 429
+// from sharing a bucket with other traffic of the same address
+func TestLoad_ProbeRoutesSkipRateLimit(t *testing.T) {
+	probePaths := map[string]bool{"/health": true, "/health/ready": true, "/health/live": true, "/metrics": true}
+
+	for _, file := range []string{"../../configs/config.yaml", "../../docker/configs/api-gateway-config.yaml"} {
+		t.Run(file, func(t *testing.T) {
+			cfg, err := config.Load(file, "test")
+			require.NoError(t, err)
+
+			probes := 0
+			for _, backend := range cfg.Backends {
+				for _, route := range backend.Routes {
+					if !probePaths[route.Path] {
+						continue
+					}
+					probes++
+					require.NotNil(t, route.RateLimit, route.ID)
+					assert.True(t, route.RateLimit.Disabled, route.ID)
+				}
+			}
+			assert.NotZero(t, probes)
+		})
+	}
+}
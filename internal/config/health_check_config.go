@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// HealthCheckConfig probes every target of a backend with a GET of path and
+// takes targets failing unhealthy_threshold probes in a row out of rotation
+type HealthCheckConfig struct {
+	Path string `mapstructure:"path"`
+	// Interval defaults to 10s and Timeout to 2s
+	Interval time.Duration `mapstructure:"interval"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	// HealthyThreshold defaults to 2 and UnhealthyThreshold to 3
+	HealthyThreshold   int `mapstructure:"healthy_threshold"`
+	UnhealthyThreshold int `mapstructure:"unhealthy_threshold"`
+	// ExpectedStatus lists the codes of a healthy response, empty accepts any 2xx
+	ExpectedStatus []int `mapstructure:"expected_status"`
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// IdentityConfig controls what backends learn about the authenticated caller.
+// Client supplied X-Gateway-* headers are removed regardless of these settings.
+type IdentityConfig struct {
+	Headers          bool                `mapstructure:"headers"`
+	StripCredentials bool                `mapstructure:"strip_credentials"`
+	Token            IdentityTokenConfig `mapstructure:"token"`
+}
+
+// IdentityTokenConfig describes the gateway signed JWT sent to backends. HS256
+// signs with secret, RS256 and ES256 with the PEM key in private_key_file.
+type IdentityTokenConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	Header         string        `mapstructure:"header"`
+	Issuer         string        `mapstructure:"issuer"`
+	TTL            time.Duration `mapstructure:"ttl"`
+	Algorithm      string        `mapstructure:"algorithm"`
+	KeyID          string        `mapstructure:"key_id"`
+	Secret         string        `mapstructure:"secret"`
+	PrivateKeyFile string        `mapstructure:"private_key_file"`
+}
@@ -0,0 +1,18 @@
+package config
+
+// TargetConfig is one upstream host of a backend
+type TargetConfig struct {
+	Host string `mapstructure:"host"`
+	// Weight defaults to 1
+	Weight int `mapstructure:"weight"`
+}
+
+// LoadBalancerConfig selects how requests are spread over the targets of a backend
+type LoadBalancerConfig struct {
+	// Strategy is round_robin (default), weighted_round_robin, least_connections,
+	// random_two_choices or consistent_hash
+	Strategy string `mapstructure:"strategy"`
+	// HashHeader or HashCookie is the request value consistent_hash routes by
+	HashHeader string `mapstructure:"hash_header"`
+	HashCookie string `mapstructure:"hash_cookie"`
+}
@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// OutlierDetectionConfig ejects targets failing consecutive_errors requests of
+// live traffic in a row for a back-off period
+type OutlierDetectionConfig struct {
+	// ConsecutiveErrors defaults to 5, 5xx responses, connection errors and timeouts count
+	ConsecutiveErrors int `mapstructure:"consecutive_errors"`
+	// BaseEjectionTime defaults to 30s, repeated ejections last longer up to MaxEjectionTime, 300s by default
+	BaseEjectionTime time.Duration `mapstructure:"base_ejection_time"`
+	MaxEjectionTime  time.Duration `mapstructure:"max_ejection_time"`
+	// MaxEjectionPercent defaults to 50, one target can always be ejected
+	MaxEjectionPercent int `mapstructure:"max_ejection_percent"`
+}
@@ -0,0 +1,19 @@
+package config
+
+// QuotasConfig limits how many requests API keys make per calendar day and
+// month, by the plan stored with each key
+type QuotasConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// DefaultPlan applies to keys without a plan or with one that is not configured,
+	// keys are unlimited when it is empty
+	DefaultPlan string `mapstructure:"default_plan"`
+	// Timezone is the IANA zone where calendar days and months begin
+	Timezone string                     `mapstructure:"timezone"`
+	Plans    map[string]QuotaPlanConfig `mapstructure:"plans"`
+}
+
+// QuotaPlanConfig holds the limits of a plan, zero leaves a period unlimited
+type QuotaPlanConfig struct {
+	Daily   int64 `mapstructure:"daily"`
+	Monthly int64 `mapstructure:"monthly"`
+}
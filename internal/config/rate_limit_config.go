@@ -0,0 +1,12 @@
+package config
+
+// RateLimitConfig overrides the default rate limit of the security section
+// for a route. Unset fields fall back to the default, disabled turns the limit
+// off for the route.
+type RateLimitConfig struct {
+	Disabled          bool    `mapstructure:"disabled"`
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int     `mapstructure:"burst"`
+	// KeyBy is consumer, ip or route
+	KeyBy string `mapstructure:"key_by"`
+}
@@ -0,0 +1,31 @@
+package config
+
+// Client certificate modes selectable with server.tls.client_auth
+const (
+	// ClientAuthNone never asks for a client certificate
+	ClientAuthNone = "none"
+	// ClientAuthRequest asks for a certificate and leaves verification to the
+	// mtls policies of the routes, each with its own CA bundle
+	ClientAuthRequest = "request"
+	// ClientAuthVerifyIfGiven verifies certificates against client_ca_file
+	// when sent, routes without an mtls policy still work without one
+	ClientAuthVerifyIfGiven = "verify_if_given"
+	// ClientAuthRequireAndVerify rejects connections without a certificate
+	// signed by client_ca_file
+	ClientAuthRequireAndVerify = "require_and_verify"
+)
+
+// TLSConfig enables HTTPS on the gateway listener. The certificate and key are
+// reloaded when they change on disk, so rotating them needs no restart.
+type TLSConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	// MinVersion is "1.2" or "1.3"
+	MinVersion string `mapstructure:"min_version"`
+	// CipherSuites restricts the TLS 1.2 cipher suites by their Go names,
+	// e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. TLS 1.3 suites are fixed.
+	CipherSuites []string `mapstructure:"cipher_suites"`
+	ClientCAFile string   `mapstructure:"client_ca_file"`
+	ClientAuth   string   `mapstructure:"client_auth"`
+}
@@ -0,0 +1,141 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// ApiKeySeparator splits the public identifier of a key from its secret,
+// e.g. "k7f3a9.9c1d...". Only the identifier is used to look a key up.
+const ApiKeySeparator = "."
+
+// legacyApiKeyIDPrefix marks records migrated from plaintext keys that were
+// issued without an identifier
+const legacyApiKeyIDPrefix = "legacy_"
+
+// ApiKey is the stored record of a consumer's API key. The secret itself is
+// only known to the consumer; the record keeps a salted hash of it.
+type ApiKey struct {
+	ID         string
+	Salt       string
+	SecretHash string
+	Owner      string
+	Scopes     []string
+	Roles      []string
+	// Plan names the usage quota plan of the key, empty for the default plan
+	Plan       string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	Disabled   bool
+}
+
+// GenerateApiKey returns a new random identifier and secret. The key handed
+// to the consumer is FormatApiKey(id, secret).
+func GenerateApiKey() (id string, secret string, err error) {
+	idBytes := make([]byte, 6)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", "", err
+	}
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(idBytes), base64.RawURLEncoding.EncodeToString(secretBytes), nil
+}
+
+// FormatApiKey joins an identifier and secret into the key presented by consumers
+func FormatApiKey(id, secret string) string {
+	return id + ApiKeySeparator + secret
+}
+
+// ParseApiKey splits a presented key into the identifier used for lookup and
+// the secret to verify. Keys without an identifier predate the
+// "<id>.<secret>" format; their identifier is derived from the whole key.
+func ParseApiKey(key string) (id string, secret string) {
+	id, secret, found := strings.Cut(key, ApiKeySeparator)
+	if found && id != "" && secret != "" {
+		return id, secret
+	}
+	return LegacyApiKeyID(key), key
+}
+
+// LegacyApiKeyID derives a stable identifier for a plaintext key issued
+// without one, without revealing the key
+func LegacyApiKeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return legacyApiKeyIDPrefix + hex.EncodeToString(sum[:8])
+}
+
+// HashApiKeySecret returns the hex encoded HMAC-SHA256 of a secret keyed with the salt
+func HashApiKeySecret(salt, secret string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(secret))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetSecret replaces the stored hash with one of the given secret under a fresh salt
+func (k *ApiKey) SetSecret(secret string) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	k.Salt = hex.EncodeToString(salt)
+	k.SecretHash = HashApiKeySecret(k.Salt, secret)
+	return nil
+}
+
+// VerifySecret compares the secret against the stored hash in constant time
+func (k *ApiKey) VerifySecret(secret string) bool {
+	if k.Salt == "" || k.SecretHash == "" {
+		return false
+	}
+	expected, err := hex.DecodeString(k.SecretHash)
+	if err != nil {
+		return false
+	}
+	actual, _ := hex.DecodeString(HashApiKeySecret(k.Salt, secret))
+	return hmac.Equal(expected, actual)
+}
+
+// IsExpired reports whether the key has an expiry in the past. Keys without
+// an expiry never expire.
+func (k *ApiKey) IsExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// CheckUsable returns why a key cannot be used right now, if anything
+func (k *ApiKey) CheckUsable(now time.Time) error {
+	if k.Disabled {
+		return domainErrors.ErrApiKeyDisabled
+	}
+	if k.IsExpired(now) {
+		return domainErrors.ErrApiKeyExpired
+	}
+	return nil
+}
+
+func (k *ApiKey) Validate() error {
+	if k.ID == "" {
+		return domainErrors.ErrApiKeyMissingID
+	}
+	if strings.Contains(k.ID, ApiKeySeparator) {
+		return domainErrors.ErrApiKeyInvalidID
+	}
+	if k.Owner == "" {
+		return domainErrors.ErrApiKeyMissingOwner
+	}
+	if k.Salt == "" || k.SecretHash == "" {
+		return domainErrors.ErrApiKeyMissingSecret
+	}
+	if !k.ExpiresAt.IsZero() && !k.CreatedAt.IsZero() && !k.ExpiresAt.After(k.CreatedAt) {
+		return domainErrors.ErrApiKeyInvalidExpiry
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiKey_CheckUsable(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		key     *entities.ApiKey
+		wantErr error
+	}{
+		{
+			name: "active key without expiry",
+			key:  &entities.ApiKey{ID: "k1", Owner: "billing"},
+		},
+		{
+			name: "active key with future expiry",
+			key:  &entities.ApiKey{ID: "k1", Owner: "billing", ExpiresAt: now.Add(time.Hour)},
+		},
+		{
+			name:    "expired key",
+			key:     &entities.ApiKey{ID: "k1", Owner: "billing", ExpiresAt: now.Add(-time.Second)},
+			wantErr: domainErrors.ErrApiKeyExpired,
+		},
+		{
+			name:    "disabled key",
+			key:     &entities.ApiKey{ID: "k1", Owner: "billing", Disabled: true},
+			wantErr: domainErrors.ErrApiKeyDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.CheckUsable(now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApiKey_Validate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		key     *entities.ApiKey
+		wantErr error
+	}{
+		{
+			name: "valid key",
+			key:  &entities.ApiKey{ID: "k1", Owner: "billing", Salt: "s", SecretHash: "h", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		},
+		{
+			name:    "missing id",
+			key:     &entities.ApiKey{Owner: "billing", Salt: "s", SecretHash: "h"},
+			wantErr: domainErrors.ErrApiKeyMissingID,
+		},
+		{
+			name:    "id with separator",
+			key:     &entities.ApiKey{ID: "k1.x", Owner: "billing", Salt: "s", SecretHash: "h"},
+			wantErr: domainErrors.ErrApiKeyInvalidID,
+		},
+		{
+			name:    "missing owner",
+			key:     &entities.ApiKey{ID: "k1", Salt: "s", SecretHash: "h"},
+			wantErr: domainErrors.ErrApiKeyMissingOwner,
+		},
+		{
+			name:    "missing secret hash",
+			key:     &entities.ApiKey{ID: "k1", Owner: "billing"},
+			wantErr: domainErrors.ErrApiKeyMissingSecret,
+		},
+		{
+			name:    "expiry before creation",
+			key:     &entities.ApiKey{ID: "k1", Owner: "billing", Salt: "s", SecretHash: "h", CreatedAt: now, ExpiresAt: now.Add(-time.Hour)},
+			wantErr: domainErrors.ErrApiKeyInvalidExpiry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseApiKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		wantID     string
+		wantSecret string
+	}{
+		{name: "id and secret", key: "k7f3a9.s3cret", wantID: "k7f3a9", wantSecret: "s3cret"},
+		{name: "secret containing separator", key: "k7f3a9.s3.cret", wantID: "k7f3a9", wantSecret: "s3.cret"},
+		{name: "legacy key", key: "key-123", wantID: entities.LegacyApiKeyID("key-123"), wantSecret: "key-123"},
+		{name: "missing secret", key: "k7f3a9.", wantID: entities.LegacyApiKeyID("k7f3a9."), wantSecret: "k7f3a9."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, secret := entities.ParseApiKey(tt.key)
+			assert.Equal(t, tt.wantID, id)
+			assert.Equal(t, tt.wantSecret, secret)
+		})
+	}
+}
+
+func TestApiKey_VerifySecret(t *testing.T) {
+	key := &entities.ApiKey{ID: "k1"}
+	require.NoError(t, key.SetSecret("s3cret"))
+
+	assert.NotEmpty(t, key.Salt)
+	assert.NotContains(t, key.SecretHash, "s3cret")
+	assert.True(t, key.VerifySecret("s3cret"))
+	assert.False(t, key.VerifySecret("s3cret2"))
+	assert.False(t, key.VerifySecret(""))
+
+	other := &entities.ApiKey{ID: "k2"}
+	require.NoError(t, other.SetSecret("s3cret"))
+	assert.NotEqual(t, key.SecretHash, other.SecretHash, "salts must differ between keys")
+}
+
+func TestLegacyApiKeyID(t *testing.T) {
+	id := entities.LegacyApiKeyID("key-123")
+
+	assert.Equal(t, id, entities.LegacyApiKeyID("key-123"))
+	assert.NotEqual(t, id, entities.LegacyApiKeyID("key-1234"))
+	assert.NotContains(t, id, "key-123")
+}
+
+func TestGenerateApiKey(t *testing.T) {
+	id, secret, err := entities.GenerateApiKey()
+	require.NoError(t, err)
+
+	parsedID, parsedSecret := entities.ParseApiKey(entities.FormatApiKey(id, secret))
+	assert.Equal(t, id, parsedID)
+	assert.Equal(t, secret, parsedSecret)
+
+	otherID, otherSecret, err := entities.GenerateApiKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, id, otherID)
+	assert.NotEqual(t, secret, otherSecret)
+}
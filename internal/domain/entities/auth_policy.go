@@ -1,13 +1,38 @@
 package entities
 
+import domainErrors "api-gateway/internal/domain/errors"
+
+const (
+	AuthTypeAPIKey              string = "api"
+	AuthTypeJWT                 string = "jwt"
+	AuthTypeOAuth2Introspection string = "oauth2_introspection"
+	AuthTypeHMAC                string = "hmac"
+	AuthTypeMTLS                string = "mtls"
+	AuthTypeBasic               string = "basic"
+	AuthTypeComposite           string = "composite"
+	AuthTypeExternal            string = "external"
+	AuthTypeNone                string = "none"
+)
+
 const (
-	AuthTypeAPIKey string = "api"
-	AuthTypeNone   string = "none"
+	AuthMatchAnyOf string = "any_of"
+	AuthMatchAllOf string = "all_of"
 )
 
 type AuthPolicy struct {
-	Type    string `json:"type"`
-	Enabled bool   `json:"enabled"`
+	Type          string               `json:"type"`
+	Enabled       bool                 `json:"enabled"`
+	JWT           *JWTConfig           `json:"jwt,omitempty"`
+	Introspection *IntrospectionConfig `json:"introspection,omitempty"`
+	HMAC          *HMACConfig          `json:"hmac,omitempty"`
+	MTLS          *MTLSConfig          `json:"mtls,omitempty"`
+	Basic         *BasicAuthConfig     `json:"basic,omitempty"`
+	External      *ExternalAuthConfig  `json:"external,omitempty"`
+	// Match and Policies describe a composite policy. Its policies are
+	// evaluated in order; any_of accepts the first one that succeeds and
+	// all_of (default) requires every one of them.
+	Match    string        `json:"match,omitempty"`
+	Policies []*AuthPolicy `json:"policies,omitempty"`
 }
 
 func (ap *AuthPolicy) RequiresAuth() bool {
@@ -25,7 +50,84 @@ func (ap *AuthPolicy) GetType() string {
 	return ap.Type
 }
 
+// MatchesAny reports whether a composite policy accepts the first policy that succeeds
+func (ap *AuthPolicy) MatchesAny() bool {
+	return ap.Match == AuthMatchAnyOf
+}
+
+// Find returns the policy of the given type, looking into the policies of a composite
+func (ap *AuthPolicy) Find(authType string) *AuthPolicy {
+	if ap.Type == authType {
+		return ap
+	}
+	for _, policy := range ap.Policies {
+		if policy.Type == authType {
+			return policy
+		}
+	}
+	return nil
+}
+
 func (ap *AuthPolicy) Validate() error {
+	if ap.Type == AuthTypeJWT && ap.Enabled {
+		if ap.JWT == nil {
+			return domainErrors.ErrAuthPolicyMissingJWTConfig
+		}
+		return ap.JWT.Validate()
+	}
 
+	if ap.Type == AuthTypeOAuth2Introspection && ap.Enabled {
+		if ap.Introspection == nil {
+			return domainErrors.ErrAuthPolicyMissingIntrospectionConfig
+		}
+		return ap.Introspection.Validate()
+	}
+
+	if ap.Type == AuthTypeHMAC && ap.Enabled {
+		if ap.HMAC == nil {
+			return domainErrors.ErrAuthPolicyMissingHMACConfig
+		}
+		return ap.HMAC.Validate()
+	}
+
+	if ap.Type == AuthTypeBasic && ap.Enabled {
+		if ap.Basic == nil {
+			return domainErrors.ErrAuthPolicyMissingBasicConfig
+		}
+		return ap.Basic.Validate()
+	}
+
+	if ap.Type == AuthTypeExternal && ap.Enabled {
+		if ap.External == nil {
+			return domainErrors.ErrAuthPolicyMissingExternalConfig
+		}
+		return ap.External.Validate()
+	}
+
+	if ap.Type == AuthTypeComposite && ap.Enabled {
+		return ap.validatePolicies()
+	}
+
+	return nil
+}
+
+func (ap *AuthPolicy) validatePolicies() error {
+	if len(ap.Policies) == 0 {
+		return domainErrors.ErrAuthPolicyMissingPolicies
+	}
+	switch ap.Match {
+	case "", AuthMatchAnyOf, AuthMatchAllOf:
+	default:
+		return domainErrors.ErrInvalidAuthPolicyMatch
+	}
+
+	for _, policy := range ap.Policies {
+		if policy == nil || policy.Type == AuthTypeNone || policy.Type == AuthTypeComposite || !policy.Enabled {
+			return domainErrors.ErrInvalidCompositePolicy
+		}
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
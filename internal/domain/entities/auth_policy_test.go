@@ -2,6 +2,7 @@ package entities_test
 
 import (
 	"testing"
+	"time"
 
 	"api-gateway/internal/domain/entities"
 
@@ -78,6 +79,219 @@ func TestAuthPolicy_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid JWT policy",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeJWT,
+				Enabled: true,
+				JWT: &entities.JWTConfig{
+					Keys: []entities.JWTKey{
+						{ID: "k1", Algorithm: entities.JWTAlgorithmHS256, Secret: "secret"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "JWT policy without config",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeJWT,
+				Enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "JWT policy with unsupported algorithm",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeJWT,
+				Enabled: true,
+				JWT: &entities.JWTConfig{
+					Keys: []entities.JWTKey{
+						{ID: "k1", Algorithm: "none"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "JWT RS256 key without public key",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeJWT,
+				Enabled: true,
+				JWT: &entities.JWTConfig{
+					Keys: []entities.JWTKey{
+						{ID: "k1", Algorithm: entities.JWTAlgorithmRS256},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "HMAC policy without config",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeHMAC,
+				Enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "HMAC credential without secret",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeHMAC,
+				Enabled: true,
+				HMAC: &entities.HMACConfig{
+					Credentials: []entities.HMACCredential{{ID: "partner"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "HMAC duplicate credential ids",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeHMAC,
+				Enabled: true,
+				HMAC: &entities.HMACConfig{
+					Credentials: []entities.HMACCredential{
+						{ID: "partner", Secret: "one"},
+						{ID: "partner", Secret: "two"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid HMAC policy",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeHMAC,
+				Enabled: true,
+				HMAC: &entities.HMACConfig{
+					Credentials: []entities.HMACCredential{{ID: "partner", Secret: "s3cret"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Basic htpasswd policy without users",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeBasic,
+				Enabled: true,
+				Basic:   &entities.BasicAuthConfig{Realm: "legacy"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Basic policy with unknown source",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeBasic,
+				Enabled: true,
+				Basic:   &entities.BasicAuthConfig{Source: "ldap"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid Basic api key policy",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeBasic,
+				Enabled: true,
+				Basic:   &entities.BasicAuthConfig{Source: entities.BasicAuthSourceApiKeys},
+			},
+			wantErr: false,
+		},
+		{
+			name: "external policy without config",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeExternal,
+				Enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "external policy with relative endpoint",
+			policy: &entities.AuthPolicy{
+				Type:     entities.AuthTypeExternal,
+				Enabled:  true,
+				External: &entities.ExternalAuthConfig{Endpoint: "authz/check"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "external policy caching all headers",
+			policy: &entities.AuthPolicy{
+				Type:     entities.AuthTypeExternal,
+				Enabled:  true,
+				External: &entities.ExternalAuthConfig{Endpoint: "http://authz.internal/check", CacheTTL: time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid external policy",
+			policy: &entities.AuthPolicy{
+				Type:     entities.AuthTypeExternal,
+				Enabled:  true,
+				External: &entities.ExternalAuthConfig{Endpoint: "http://authz.internal/check"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "composite policy without policies",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeComposite,
+				Enabled: true,
+				Match:   entities.AuthMatchAnyOf,
+			},
+			wantErr: true,
+		},
+		{
+			name: "composite policy with unknown match",
+			policy: &entities.AuthPolicy{
+				Type:     entities.AuthTypeComposite,
+				Enabled:  true,
+				Match:    "one_of",
+				Policies: []*entities.AuthPolicy{{Type: entities.AuthTypeAPIKey, Enabled: true}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nested composite policy",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeComposite,
+				Enabled: true,
+				Policies: []*entities.AuthPolicy{{
+					Type:     entities.AuthTypeComposite,
+					Enabled:  true,
+					Policies: []*entities.AuthPolicy{{Type: entities.AuthTypeAPIKey, Enabled: true}},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "composite policy with invalid member",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeComposite,
+				Enabled: true,
+				Match:   entities.AuthMatchAnyOf,
+				Policies: []*entities.AuthPolicy{
+					{Type: entities.AuthTypeAPIKey, Enabled: true},
+					{Type: entities.AuthTypeJWT, Enabled: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid composite policy",
+			policy: &entities.AuthPolicy{
+				Type:    entities.AuthTypeComposite,
+				Enabled: true,
+				Match:   entities.AuthMatchAnyOf,
+				Policies: []*entities.AuthPolicy{
+					{Type: entities.AuthTypeJWT, Enabled: true, JWT: &entities.JWTConfig{
+						Keys: []entities.JWTKey{{ID: "k1", Algorithm: entities.JWTAlgorithmHS256, Secret: "s3cret"}},
+					}},
+					{Type: entities.AuthTypeAPIKey, Enabled: true},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "none type always valid",
 			policy: &entities.AuthPolicy{
@@ -99,3 +313,21 @@ func TestAuthPolicy_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestBasicAuthConfig_Challenge(t *testing.T) {
+	assert.Equal(t, `Basic realm="api-gateway", charset="UTF-8"`, (*entities.BasicAuthConfig)(nil).Challenge())
+	assert.Equal(t, `Basic realm="Legacy \"reports\"", charset="UTF-8"`, (&entities.BasicAuthConfig{Realm: `Legacy "reports"`}).Challenge())
+}
+
+func TestAuthPolicy_Find(t *testing.T) {
+	basic := &entities.AuthPolicy{Type: entities.AuthTypeBasic, Enabled: true}
+	composite := &entities.AuthPolicy{
+		Type:     entities.AuthTypeComposite,
+		Enabled:  true,
+		Policies: []*entities.AuthPolicy{{Type: entities.AuthTypeAPIKey, Enabled: true}, basic},
+	}
+
+	assert.Same(t, basic, composite.Find(entities.AuthTypeBasic))
+	assert.Same(t, basic, basic.Find(entities.AuthTypeBasic))
+	assert.Nil(t, composite.Find(entities.AuthTypeMTLS))
+}
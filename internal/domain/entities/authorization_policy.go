@@ -0,0 +1,79 @@
+package entities
+
+import domainErrors "api-gateway/internal/domain/errors"
+
+const (
+	AuthorizationMatchAllOf string = "all_of"
+	AuthorizationMatchAnyOf string = "any_of"
+)
+
+// AuthorizationPolicy lists the scopes and roles a principal needs to call a route.
+// With all_of every listed scope and role is required, with any_of a single one suffices.
+// A rule, when set, has to hold as well.
+type AuthorizationPolicy struct {
+	RequiredScopes []string           `json:"requiredScopes,omitempty"`
+	RequiredRoles  []string           `json:"requiredRoles,omitempty"`
+	Match          string             `json:"match,omitempty"`
+	Rule           *AuthorizationRule `json:"rule,omitempty"`
+}
+
+func (p *AuthorizationPolicy) IsEmpty() bool {
+	return !p.requiresGrants() && p.Rule == nil
+}
+
+func (p *AuthorizationPolicy) requiresGrants() bool {
+	return len(p.RequiredScopes) > 0 || len(p.RequiredRoles) > 0
+}
+
+// Authorize checks the scopes and roles of the principal against the policy,
+// the rule is evaluated separately since it needs the request
+func (p *AuthorizationPolicy) Authorize(principal *Principal) error {
+	if !p.requiresGrants() {
+		return nil
+	}
+
+	if principal == nil {
+		return domainErrors.ErrForbidden
+	}
+
+	if p.Match == AuthorizationMatchAnyOf {
+		for _, scope := range p.RequiredScopes {
+			if principal.HasScope(scope) {
+				return nil
+			}
+		}
+		for _, role := range p.RequiredRoles {
+			if principal.HasRole(role) {
+				return nil
+			}
+		}
+		if len(p.RequiredScopes) > 0 {
+			return domainErrors.ErrInsufficientScope
+		}
+		return domainErrors.ErrInsufficientRole
+	}
+
+	for _, scope := range p.RequiredScopes {
+		if !principal.HasScope(scope) {
+			return domainErrors.ErrInsufficientScope
+		}
+	}
+	for _, role := range p.RequiredRoles {
+		if !principal.HasRole(role) {
+			return domainErrors.ErrInsufficientRole
+		}
+	}
+	return nil
+}
+
+func (p *AuthorizationPolicy) Validate() error {
+	if p.Rule != nil && p.Rule.program == nil {
+		return domainErrors.ErrInvalidAuthorizationRule
+	}
+
+	switch p.Match {
+	case "", AuthorizationMatchAllOf, AuthorizationMatchAnyOf:
+		return nil
+	}
+	return domainErrors.ErrInvalidAuthorizationMatch
+}
@@ -0,0 +1,111 @@
+package entities_test
+
+import (
+	"testing"
+
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizationPolicy_Authorize(t *testing.T) {
+	writer := &entities.Principal{
+		Subject: "user-1",
+		Scopes:  []string{"orders:read", "orders:write"},
+		Roles:   []string{"clerk"},
+	}
+	reader := &entities.Principal{
+		Subject: "user-2",
+		Scopes:  []string{"orders:read"},
+	}
+
+	tests := []struct {
+		name      string
+		policy    *entities.AuthorizationPolicy
+		principal *entities.Principal
+		wantErr   error
+	}{
+		{
+			name:      "empty policy allows anyone",
+			policy:    &entities.AuthorizationPolicy{},
+			principal: nil,
+		},
+		{
+			name: "all_of satisfied",
+			policy: &entities.AuthorizationPolicy{
+				RequiredScopes: []string{"orders:read", "orders:write"},
+				RequiredRoles:  []string{"clerk"},
+			},
+			principal: writer,
+		},
+		{
+			name: "all_of missing scope",
+			policy: &entities.AuthorizationPolicy{
+				RequiredScopes: []string{"orders:read", "orders:write"},
+				Match:          entities.AuthorizationMatchAllOf,
+			},
+			principal: reader,
+			wantErr:   domainErrors.ErrInsufficientScope,
+		},
+		{
+			name: "all_of missing role",
+			policy: &entities.AuthorizationPolicy{
+				RequiredRoles: []string{"admin"},
+			},
+			principal: writer,
+			wantErr:   domainErrors.ErrInsufficientRole,
+		},
+		{
+			name: "any_of satisfied by one scope",
+			policy: &entities.AuthorizationPolicy{
+				RequiredScopes: []string{"orders:admin", "orders:read"},
+				Match:          entities.AuthorizationMatchAnyOf,
+			},
+			principal: reader,
+		},
+		{
+			name: "any_of satisfied by role",
+			policy: &entities.AuthorizationPolicy{
+				RequiredScopes: []string{"orders:admin"},
+				RequiredRoles:  []string{"clerk"},
+				Match:          entities.AuthorizationMatchAnyOf,
+			},
+			principal: writer,
+		},
+		{
+			name: "any_of none matched",
+			policy: &entities.AuthorizationPolicy{
+				RequiredScopes: []string{"orders:admin"},
+				Match:          entities.AuthorizationMatchAnyOf,
+			},
+			principal: reader,
+			wantErr:   domainErrors.ErrInsufficientScope,
+		},
+		{
+			name: "no principal",
+			policy: &entities.AuthorizationPolicy{
+				RequiredScopes: []string{"orders:read"},
+			},
+			principal: nil,
+			wantErr:   domainErrors.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Authorize(tt.principal)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthorizationPolicy_Validate(t *testing.T) {
+	assert.NoError(t, (&entities.AuthorizationPolicy{Match: entities.AuthorizationMatchAnyOf}).Validate())
+	assert.NoError(t, (&entities.AuthorizationPolicy{}).Validate())
+	assert.ErrorIs(t, (&entities.AuthorizationPolicy{Match: "some_of"}).Validate(), domainErrors.ErrInvalidAuthorizationMatch)
+}
@@ -0,0 +1,117 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"api-gateway/pkg/expr"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ruleVariables are the names an authorization rule can refer to
+var ruleVariables = []string{"method", "path", "query", "headers", "claims", "principal", "route"}
+
+// AuthorizationRule is an expression over the request and the caller that has
+// to evaluate to true, for example
+//
+//	claims.tenant == path.params.tenantId && method in ["GET", "HEAD"]
+type AuthorizationRule struct {
+	program *expr.Program
+}
+
+// RuleInput is what an authorization rule is evaluated against
+type RuleInput struct {
+	Request *HTTPRequest
+	// PathParams are the values of the :name segments of the matched route
+	PathParams map[string]string
+	Principal  *Principal
+}
+
+// NewAuthorizationRule compiles a rule, rejecting syntax errors and unknown variables
+func NewAuthorizationRule(expression string) (*AuthorizationRule, error) {
+	program, err := expr.Compile(expression, ruleVariables...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidAuthorizationRule, err)
+	}
+	return &AuthorizationRule{program: program}, nil
+}
+
+// Evaluate reports whether the rule holds for the input
+func (r *AuthorizationRule) Evaluate(input RuleInput) (bool, error) {
+	if r.program == nil {
+		return false, errors.New("authorization rule was not compiled")
+	}
+	return r.program.Eval(input.variables())
+}
+
+func (r *AuthorizationRule) String() string {
+	if r.program == nil {
+		return ""
+	}
+	return r.program.String()
+}
+
+func (r *AuthorizationRule) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// variables exposes the input to the rule:
+//
+//	method      request method
+//	path        value (escaped request path) and params
+//	query       first value of every query parameter
+//	headers     first value of every header, by lower case name
+//	claims      claims of the principal, empty without one
+//	principal   subject, auth_type, key_id, scopes and roles, null without one
+//	route       id
+func (in RuleInput) variables() map[string]interface{} {
+	request := in.Request
+	if request == nil {
+		request = &HTTPRequest{}
+	}
+
+	query := make(map[string]interface{})
+	if values, err := url.ParseQuery(request.RawQuery); err == nil {
+		for name, value := range values {
+			query[name] = value[0]
+		}
+	}
+
+	headers := make(map[string]interface{}, len(request.Headers))
+	for name, value := range request.Headers {
+		if len(value) > 0 {
+			headers[strings.ToLower(name)] = value[0]
+		}
+	}
+
+	params := in.PathParams
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	claims := map[string]interface{}{}
+	var principal interface{}
+	if p := in.Principal; p != nil {
+		if p.Claims != nil {
+			claims = p.Claims
+		}
+		principal = map[string]interface{}{
+			"subject":   p.Subject,
+			"auth_type": p.AuthType,
+			"key_id":    p.KeyID,
+			"scopes":    p.Scopes,
+			"roles":     p.Roles,
+		}
+	}
+
+	return map[string]interface{}{
+		"method":    request.Method,
+		"path":      map[string]interface{}{"value": request.Path, "params": params},
+		"query":     query,
+		"headers":   headers,
+		"claims":    claims,
+		"principal": principal,
+		"route":     map[string]interface{}{"id": request.RouteID},
+	}
+}
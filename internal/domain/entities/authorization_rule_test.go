@@ -0,0 +1,74 @@
+package entities_test
+
+import (
+	"testing"
+
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizationRule_Evaluate(t *testing.T) {
+	request := &entities.HTTPRequest{
+		RouteID:  "tenant-orders",
+		Method:   "GET",
+		Path:     "/api/tenants/acme/orders",
+		RawQuery: "view=summary&view=full",
+		Headers:  map[string][]string{"X-Region": {"eu"}},
+	}
+	principal := &entities.Principal{
+		Subject:  "user-1",
+		AuthType: entities.AuthTypeJWT,
+		Scopes:   []string{"orders:read"},
+		Claims:   map[string]interface{}{"tenant": "acme"},
+	}
+	params := map[string]string{"tenantId": "acme"}
+
+	tests := []struct {
+		name      string
+		rule      string
+		principal *entities.Principal
+		want      bool
+	}{
+		{name: "tenant claim matches path param", rule: `claims.tenant == path.params.tenantId && method in ["GET", "HEAD"]`, principal: principal, want: true},
+		{name: "query uses the first value", rule: `query.view == "summary"`, principal: principal, want: true},
+		{name: "headers are lower case", rule: `headers["x-region"] == "eu"`, principal: principal, want: true},
+		{name: "principal attributes", rule: `principal.subject == "user-1" && "orders:read" in principal.scopes && principal.auth_type == "jwt"`, principal: principal, want: true},
+		{name: "route and path", rule: `route.id == "tenant-orders" && path.value.startsWith("/api/tenants/")`, principal: principal, want: true},
+		{name: "anonymous has no claims", rule: `claims.tenant == path.params.tenantId`, principal: nil, want: false},
+		{name: "anonymous principal is null", rule: `principal == null`, principal: nil, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := entities.NewAuthorizationRule(tt.rule)
+			require.NoError(t, err)
+
+			got, err := rule.Evaluate(entities.RuleInput{Request: request, PathParams: params, Principal: tt.principal})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.rule, rule.String())
+		})
+	}
+}
+
+func TestNewAuthorizationRule_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{name: "syntax error", rule: `claims.tenant ==`},
+		{name: "unknown variable", rule: `claim.tenant == "acme"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entities.NewAuthorizationRule(tt.rule)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidAuthorizationRule)
+		})
+	}
+}
@@ -7,28 +7,73 @@ import (
 )
 
 type Backend struct {
-	Id              string
-	Host            string
-	PathPrefix      string
+	Id         string
+	Host       string
+	PathPrefix string
+	Timeout    time.Duration
+	// LastHealthCheck and Healthy are only kept on the health checker's own
+	// copy of the backend. The backends of routes never see probe results,
+	// ports.HealthChecker is the one source of backend and target health.
 	LastHealthCheck time.Time
-	Timeout         time.Duration
 	Healthy         bool
+	// Targets are the upstream hosts requests are balanced over, Host is the first of them
+	Targets       []Target
+	LoadBalancing LoadBalancing
+	// ConcurrencyLimit caps the requests in flight to the backend, nil leaves them unlimited
+	ConcurrencyLimit *ConcurrencyLimit
+	// HealthCheck probes the targets in the background, nil keeps all of them in rotation
+	HealthCheck *HealthCheck
+	// OutlierDetection ejects targets failing live traffic, nil never ejects any
+	OutlierDetection *OutlierDetection
 }
 
 func (b *Backend) GetURL(requestPath string) string {
 	return b.Host + b.PathPrefix + requestPath
 }
 
+// TargetURL is the URL of a request path on one of the backend's targets
+func (b *Backend) TargetURL(target *Target, requestPath string) string {
+	return target.Host + b.PathPrefix + requestPath
+}
+
 func (b *Backend) IsHealthy() bool {
 	return b.Healthy
 }
 
 func (b *Backend) Validate() error {
-	if b.Host == "" {
+	if err := validateHost(b.Host); err != nil {
+		return err
+	}
+	for _, target := range b.Targets {
+		if err := target.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := b.LoadBalancing.Validate(); err != nil {
+		return err
+	}
+	if b.ConcurrencyLimit != nil {
+		if err := b.ConcurrencyLimit.Validate(); err != nil {
+			return err
+		}
+	}
+	if b.HealthCheck != nil {
+		if err := b.HealthCheck.Validate(); err != nil {
+			return err
+		}
+	}
+	if b.OutlierDetection != nil {
+		return b.OutlierDetection.Validate()
+	}
+	return nil
+}
+
+func validateHost(host string) error {
+	if host == "" {
 		return domainErrors.ErrBackendMissingHost
 	}
 
-	_, err := url.ParseRequestURI(b.Host)
+	_, err := url.ParseRequestURI(host)
 
 	if err != nil {
 		return domainErrors.ErrBackendInvalidHost
@@ -35,6 +35,12 @@ func TestBackend_GetURL(t *testing.T) {
 	}
 }
 
+func TestBackend_TargetURL(t *testing.T) {
+	backend := &entities.Backend{Host: "http://service-a:8080", PathPrefix: "/v2"}
+
+	assert.Equal(t, "http://service-b:8080/v2/users", backend.TargetURL(&entities.Target{Host: "http://service-b:8080", Weight: 1}, "/users"))
+}
+
 func TestBackend_IsHealthy(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -96,6 +102,154 @@ func TestBackend_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "fixed concurrency limit",
+			backend: &entities.Backend{
+				Host:             "http://service:8080",
+				ConcurrencyLimit: &entities.ConcurrencyLimit{MaxRequests: 10, MaxQueue: 20, QueueTimeout: time.Second},
+			},
+			wantErr: false,
+		},
+		{
+			name: "concurrency limit without slots",
+			backend: &entities.Backend{
+				Host:             "http://service:8080",
+				ConcurrencyLimit: &entities.ConcurrencyLimit{MaxQueue: 20},
+			},
+			wantErr: true,
+		},
+		{
+			name: "adaptive limit with minimum above maximum",
+			backend: &entities.Backend{
+				Host:             "http://service:8080",
+				ConcurrencyLimit: &entities.ConcurrencyLimit{Algorithm: entities.ConcurrencyAlgorithmAIMD, MaxRequests: 10, MinRequests: 20},
+			},
+			wantErr: true,
+		},
+		{
+			name: "weighted targets",
+			backend: &entities.Backend{
+				Host:          "http://service-1:8080",
+				Targets:       []entities.Target{{Host: "http://service-1:8080", Weight: 3}, {Host: "http://service-2:8080", Weight: 1}},
+				LoadBalancing: entities.LoadBalancing{Strategy: entities.BalancerWeightedRoundRobin},
+			},
+			wantErr: false,
+		},
+		{
+			name: "target without weight",
+			backend: &entities.Backend{
+				Host:    "http://service-1:8080",
+				Targets: []entities.Target{{Host: "http://service-1:8080"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "target weight above maximum",
+			backend: &entities.Backend{
+				Host:    "http://service-1:8080",
+				Targets: []entities.Target{{Host: "http://service-1:8080", Weight: entities.MaxTargetWeight + 1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid target host",
+			backend: &entities.Backend{
+				Host:    "http://service-1:8080",
+				Targets: []entities.Target{{Host: "service-2", Weight: 1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown balancer strategy",
+			backend: &entities.Backend{
+				Host:          "http://service-1:8080",
+				LoadBalancing: entities.LoadBalancing{Strategy: "fastest"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "consistent hash by header",
+			backend: &entities.Backend{
+				Host:          "http://service-1:8080",
+				LoadBalancing: entities.LoadBalancing{Strategy: entities.BalancerConsistentHash, HashHeader: "X-User-ID"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "consistent hash without key",
+			backend: &entities.Backend{
+				Host:          "http://service-1:8080",
+				LoadBalancing: entities.LoadBalancing{Strategy: entities.BalancerConsistentHash},
+			},
+			wantErr: true,
+		},
+		{
+			name: "health check",
+			backend: &entities.Backend{
+				Host:        "http://service-1:8080",
+				HealthCheck: &entities.HealthCheck{Path: "/healthz", Interval: 10 * time.Second, Timeout: 2 * time.Second, HealthyThreshold: 2, UnhealthyThreshold: 3},
+			},
+			wantErr: false,
+		},
+		{
+			name: "health check without path",
+			backend: &entities.Backend{
+				Host:        "http://service-1:8080",
+				HealthCheck: &entities.HealthCheck{Interval: 10 * time.Second, Timeout: 2 * time.Second, HealthyThreshold: 2, UnhealthyThreshold: 3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "health check timeout longer than interval",
+			backend: &entities.Backend{
+				Host:        "http://service-1:8080",
+				HealthCheck: &entities.HealthCheck{Path: "/healthz", Interval: time.Second, Timeout: 2 * time.Second, HealthyThreshold: 2, UnhealthyThreshold: 3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "health check expecting an invalid status",
+			backend: &entities.Backend{
+				Host: "http://service-1:8080",
+				HealthCheck: &entities.HealthCheck{
+					Path: "/healthz", Interval: 10 * time.Second, Timeout: 2 * time.Second, HealthyThreshold: 2, UnhealthyThreshold: 3,
+					ExpectedStatuses: []int{2000},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "outlier detection",
+			backend: &entities.Backend{
+				Host:             "http://service-1:8080",
+				OutlierDetection: &entities.OutlierDetection{ConsecutiveErrors: 5, BaseEjectionTime: 30 * time.Second, MaxEjectionTime: 5 * time.Minute, MaxEjectionPercent: 50},
+			},
+			wantErr: false,
+		},
+		{
+			name: "outlier detection with base ejection time above maximum",
+			backend: &entities.Backend{
+				Host:             "http://service-1:8080",
+				OutlierDetection: &entities.OutlierDetection{ConsecutiveErrors: 5, BaseEjectionTime: time.Minute, MaxEjectionTime: 30 * time.Second, MaxEjectionPercent: 50},
+			},
+			wantErr: true,
+		},
+		{
+			name: "outlier detection ejecting no targets",
+			backend: &entities.Backend{
+				Host:             "http://service-1:8080",
+				OutlierDetection: &entities.OutlierDetection{ConsecutiveErrors: 5, BaseEjectionTime: 30 * time.Second, MaxEjectionTime: 5 * time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown concurrency algorithm",
+			backend: &entities.Backend{
+				Host:             "http://service:8080",
+				ConcurrencyLimit: &entities.ConcurrencyLimit{Algorithm: "vegas", MaxRequests: 10, MinRequests: 1},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"strings"
+)
+
+const (
+	// BasicAuthSourceHtpasswd checks passwords against the hashes of an htpasswd file
+	BasicAuthSourceHtpasswd = "htpasswd"
+	// BasicAuthSourceApiKeys treats the user name as an API key id and the password as its secret
+	BasicAuthSourceApiKeys = "api_keys"
+
+	defaultBasicAuthRealm = "api-gateway"
+)
+
+// BasicAuthConfig describes where the credentials of a basic auth route come
+// from and the realm clients are challenged with
+type BasicAuthConfig struct {
+	Realm  string `json:"realm,omitempty"`
+	Source string `json:"source,omitempty"`
+	// Users maps user names to the bcrypt or argon2 password hashes of an htpasswd file
+	Users map[string]string `json:"-"`
+}
+
+func (c *BasicAuthConfig) Validate() error {
+	switch c.Source {
+	case "", BasicAuthSourceHtpasswd:
+		if len(c.Users) == 0 {
+			return domainErrors.ErrBasicAuthMissingUsers
+		}
+	case BasicAuthSourceApiKeys:
+	default:
+		return domainErrors.ErrBasicAuthInvalidSource
+	}
+	return nil
+}
+
+// Challenge is the WWW-Authenticate value sent with rejected requests
+func (c *BasicAuthConfig) Challenge() string {
+	realm := defaultBasicAuthRealm
+	if c != nil && c.Realm != "" {
+		realm = c.Realm
+	}
+	realm = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(realm)
+	return `Basic realm="` + realm + `", charset="UTF-8"`
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"time"
+)
+
+// How the number of requests in flight to a backend is limited
+const (
+	// ConcurrencyAlgorithmFixed keeps the limit at MaxRequests
+	ConcurrencyAlgorithmFixed string = "fixed"
+	// ConcurrencyAlgorithmAIMD grows the limit by one per round of successful
+	// requests and cuts it on failed or slow ones
+	ConcurrencyAlgorithmAIMD string = "aimd"
+	// ConcurrencyAlgorithmGradient shrinks the limit as latency rises above its
+	// long term average and grows it while latency is steady
+	ConcurrencyAlgorithmGradient string = "gradient"
+)
+
+// ConcurrencyLimit caps the requests in flight to a backend. Requests beyond
+// the limit wait in a queue of MaxQueue for up to QueueTimeout and are shed
+// when the queue is full or the wait runs out. Adaptive algorithms move the
+// limit between MinRequests and MaxRequests.
+type ConcurrencyLimit struct {
+	Algorithm    string        `json:"algorithm"`
+	MaxRequests  int           `json:"maxRequests"`
+	MinRequests  int           `json:"minRequests,omitempty"`
+	MaxQueue     int           `json:"maxQueue"`
+	QueueTimeout time.Duration `json:"queueTimeout"`
+	// LatencyThreshold makes AIMD treat slower requests like failed ones, zero only counts failures
+	LatencyThreshold time.Duration `json:"latencyThreshold,omitempty"`
+}
+
+// ConcurrencyOutcome is what a finished request tells about the load of a backend
+type ConcurrencyOutcome int
+
+const (
+	ConcurrencyOutcomeSuccess ConcurrencyOutcome = iota
+	// ConcurrencyOutcomeDropped is a request the backend failed to serve, e.g. a
+	// timeout or a 503, a sign that it is overloaded
+	ConcurrencyOutcomeDropped
+	// ConcurrencyOutcomeIgnored is a request that tells nothing about the
+	// backend's load, e.g. one that could not be sent
+	ConcurrencyOutcomeIgnored
+)
+
+func (l *ConcurrencyLimit) Validate() error {
+	if l.MaxRequests < 1 || l.MaxQueue < 0 || l.QueueTimeout < 0 || l.LatencyThreshold < 0 {
+		return domainErrors.ErrInvalidConcurrencyLimit
+	}
+
+	switch l.Algorithm {
+	case "", ConcurrencyAlgorithmFixed:
+		return nil
+	case ConcurrencyAlgorithmAIMD, ConcurrencyAlgorithmGradient:
+		if l.MinRequests < 1 || l.MinRequests > l.MaxRequests {
+			return domainErrors.ErrInvalidConcurrencyLimit
+		}
+		return nil
+	}
+	return domainErrors.ErrInvalidConcurrencyAlgorithm
+}
+
+// IsAdaptive reports whether the limit follows the observed load
+func (l *ConcurrencyLimit) IsAdaptive() bool {
+	return l.Algorithm == ConcurrencyAlgorithmAIMD || l.Algorithm == ConcurrencyAlgorithmGradient
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"net/url"
+	"time"
+)
+
+// ExternalAuthConfig describes an HTTP service that decides whether a request
+// is allowed and which headers to change before it is forwarded
+type ExternalAuthConfig struct {
+	Endpoint string        `json:"endpoint"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+	// CacheTTL keeps decisions for identical requests; zero disables caching.
+	// Caching requires ForwardHeaders, all headers include per request ids.
+	CacheTTL time.Duration `json:"cacheTtl,omitempty"`
+	// FailOpen allows requests while the service cannot be reached
+	FailOpen bool `json:"failOpen,omitempty"`
+	// ForwardHeaders limits the request headers sent to the service, all when empty
+	ForwardHeaders []string `json:"forwardHeaders,omitempty"`
+}
+
+func (c *ExternalAuthConfig) Validate() error {
+	if c.Endpoint == "" {
+		return domainErrors.ErrExternalAuthMissingEndpoint
+	}
+
+	if _, err := url.ParseRequestURI(c.Endpoint); err != nil {
+		return domainErrors.ErrExternalAuthInvalidEndpoint
+	}
+
+	if c.CacheTTL > 0 && len(c.ForwardHeaders) == 0 {
+		return domainErrors.ErrExternalAuthCacheWithoutForwardHeaders
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HealthCheck probes every target of a backend with a GET of Path. A target
+// is taken out of rotation after UnhealthyThreshold failed probes in a row and
+// put back after HealthyThreshold successful ones.
+type HealthCheck struct {
+	Path               string        `json:"path"`
+	Interval           time.Duration `json:"interval"`
+	Timeout            time.Duration `json:"timeout"`
+	HealthyThreshold   int           `json:"healthyThreshold"`
+	UnhealthyThreshold int           `json:"unhealthyThreshold"`
+	// ExpectedStatuses are the response codes of a healthy target, empty accepts any 2xx
+	ExpectedStatuses []int `json:"expectedStatuses,omitempty"`
+}
+
+// TargetHealth is what the health checker last found out about a target. A
+// target is healthy when it passes its health checks and is not ejected.
+type TargetHealth struct {
+	Host      string    `json:"host"`
+	Healthy   bool      `json:"healthy"`
+	LastCheck time.Time `json:"lastCheck,omitzero"`
+	LastError string    `json:"lastError,omitempty"`
+	// EjectedUntil is set while outlier detection keeps the target out of rotation
+	EjectedUntil time.Time `json:"ejectedUntil,omitzero"`
+}
+
+// BackendHealth is the health of a backend and each of its targets, the
+// backend is healthy while any of its targets is
+type BackendHealth struct {
+	BackendID string         `json:"backendId"`
+	Healthy   bool           `json:"healthy"`
+	LastCheck time.Time      `json:"lastCheck"`
+	Targets   []TargetHealth `json:"targets"`
+}
+
+func (h *HealthCheck) Validate() error {
+	if !strings.HasPrefix(h.Path, "/") || h.Interval <= 0 || h.Timeout <= 0 || h.Timeout > h.Interval {
+		return domainErrors.ErrInvalidHealthCheck
+	}
+	if h.HealthyThreshold < 1 || h.UnhealthyThreshold < 1 {
+		return domainErrors.ErrInvalidHealthCheck
+	}
+	for _, status := range h.ExpectedStatuses {
+		if status < 100 || status > 599 {
+			return domainErrors.ErrInvalidHealthCheck
+		}
+	}
+	return nil
+}
+
+// IsExpectedStatus reports whether a probe response code means the target is healthy
+func (h *HealthCheck) IsExpectedStatus(status int) bool {
+	if len(h.ExpectedStatuses) == 0 {
+		return status >= http.StatusOK && status < http.StatusMultipleChoices
+	}
+	for _, expected := range h.ExpectedStatuses {
+		if status == expected {
+			return true
+		}
+	}
+	return false
+}
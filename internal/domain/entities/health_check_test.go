@@ -0,0 +1,32 @@
+package entities_test
+
+import (
+	"net/http"
+	"testing"
+
+	"api-gateway/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheck_IsExpectedStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []int
+		status   int
+		want     bool
+	}{
+		{name: "any 2xx by default", status: http.StatusNoContent, want: true},
+		{name: "redirect by default", status: http.StatusFound, want: false},
+		{name: "server error by default", status: http.StatusServiceUnavailable, want: false},
+		{name: "listed status", expected: []int{http.StatusOK, http.StatusTooManyRequests}, status: http.StatusTooManyRequests, want: true},
+		{name: "unlisted status", expected: []int{http.StatusOK}, status: http.StatusNoContent, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := &entities.HealthCheck{ExpectedStatuses: tt.expected}
+			assert.Equal(t, tt.want, check.IsExpectedStatus(tt.status))
+		})
+	}
+}
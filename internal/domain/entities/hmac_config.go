@@ -0,0 +1,54 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"time"
+)
+
+// HMACConfig describes the shared secrets clients sign requests with
+type HMACConfig struct {
+	Credentials []HMACCredential `json:"credentials,omitempty"`
+	// SignedHeaders must be covered by every signature in addition to the
+	// headers a client chooses to sign
+	SignedHeaders []string `json:"signedHeaders,omitempty"`
+	// MaxClockSkew is how far a request timestamp may be from the gateway clock
+	MaxClockSkew time.Duration `json:"maxClockSkew,omitempty"`
+}
+
+// HMACCredential is a shared secret issued to one client. The principal of
+// a signed request is built from its owner, scopes and roles.
+type HMACCredential struct {
+	ID     string   `json:"id"`
+	Secret string   `json:"-"`
+	Owner  string   `json:"owner,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+}
+
+func (c *HMACConfig) Validate() error {
+	if len(c.Credentials) == 0 {
+		return domainErrors.ErrHMACMissingCredentials
+	}
+
+	seen := make(map[string]bool, len(c.Credentials))
+	for _, credential := range c.Credentials {
+		if credential.ID == "" || credential.Secret == "" {
+			return domainErrors.ErrHMACInvalidCredential
+		}
+		if seen[credential.ID] {
+			return domainErrors.ErrHMACDuplicateCredential
+		}
+		seen[credential.ID] = true
+	}
+	return nil
+}
+
+// Credential returns the credential issued under an id
+func (c *HMACConfig) Credential(id string) (*HMACCredential, bool) {
+	for i := range c.Credentials {
+		if c.Credentials[i].ID == id {
+			return &c.Credentials[i], true
+		}
+	}
+	return nil, false
+}
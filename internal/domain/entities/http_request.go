@@ -0,0 +1,23 @@
+package entities
+
+import "crypto/x509"
+
+// HTTPRequest is the part of an incoming request that policies covering more
+// than a token, such as request signatures, are evaluated against
+type HTTPRequest struct {
+	// RouteID is the id of the route the request matched
+	RouteID string
+	Method  string
+	Host    string
+	// Path is the escaped path as sent by the client, before any rewriting
+	Path     string
+	RawQuery string
+	Headers  map[string][]string
+	Body     []byte
+	// ClientCertificates are the certificates the client presented over TLS, leaf first
+	ClientCertificates []*x509.Certificate
+	// ClientCertificateVerified is set when the listener verified the chain
+	ClientCertificateVerified bool
+	// Principal is the caller established by the policies before this one in an all_of composite
+	Principal *Principal
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"net/url"
+	"time"
+)
+
+// IntrospectionConfig describes an RFC 7662 token introspection endpoint
+type IntrospectionConfig struct {
+	Endpoint         string        `json:"endpoint"`
+	ClientID         string        `json:"clientId,omitempty"`
+	ClientSecret     string        `json:"-"`
+	RequiredScopes   []string      `json:"requiredScopes,omitempty"`
+	CacheTTL         time.Duration `json:"cacheTtl,omitempty"`
+	NegativeCacheTTL time.Duration `json:"negativeCacheTtl,omitempty"`
+	Timeout          time.Duration `json:"timeout,omitempty"`
+}
+
+func (c *IntrospectionConfig) Validate() error {
+	if c.Endpoint == "" {
+		return domainErrors.ErrIntrospectionMissingEndpoint
+	}
+
+	if _, err := url.ParseRequestURI(c.Endpoint); err != nil {
+		return domainErrors.ErrIntrospectionInvalidEndpoint
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"time"
+)
+
+const (
+	JWTAlgorithmHS256 string = "HS256"
+	JWTAlgorithmRS256 string = "RS256"
+	JWTAlgorithmES256 string = "ES256"
+)
+
+// JWTConfig describes how bearer tokens are verified for a route. Keys can be
+// configured statically, loaded from a JWKS source, or both.
+type JWTConfig struct {
+	Issuer    string        `json:"issuer,omitempty"`
+	Audiences []string      `json:"audiences,omitempty"`
+	Keys      []JWTKey      `json:"keys,omitempty"`
+	ClockSkew time.Duration `json:"clockSkew,omitempty"`
+	JWKS      *JWKSConfig   `json:"jwks,omitempty"`
+}
+
+// JWKSConfig points at a JSON Web Key Set served over HTTP or stored on disk
+type JWKSConfig struct {
+	URL             string        `json:"url,omitempty"`
+	File            string        `json:"file,omitempty"`
+	RefreshInterval time.Duration `json:"refreshInterval,omitempty"`
+	CacheTTL        time.Duration `json:"cacheTtl,omitempty"`
+}
+
+// JWTKey is a single verification key. Secret is used for HS256,
+// PublicKey (PEM encoded) for RS256 and ES256.
+type JWTKey struct {
+	ID        string `json:"id,omitempty"`
+	Algorithm string `json:"algorithm"`
+	Secret    string `json:"-"`
+	PublicKey string `json:"-"`
+}
+
+// Algorithms returns the distinct signing algorithms accepted by the configured keys.
+// Key sets only ever provide asymmetric keys, so a JWKS source enables RS256 and ES256.
+func (c *JWTConfig) Algorithms() []string {
+	seen := make(map[string]bool)
+	var algorithms []string
+	add := func(algorithm string) {
+		if !seen[algorithm] {
+			seen[algorithm] = true
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+
+	for _, key := range c.Keys {
+		add(key.Algorithm)
+	}
+	if c.JWKS != nil {
+		add(JWTAlgorithmRS256)
+		add(JWTAlgorithmES256)
+	}
+	return algorithms
+}
+
+// Source returns the location of the key set, preferring the URL over the file
+func (c *JWKSConfig) Source() string {
+	if c.URL != "" {
+		return c.URL
+	}
+	return c.File
+}
+
+func (c *JWKSConfig) Validate() error {
+	if c.URL == "" && c.File == "" {
+		return domainErrors.ErrJWKSMissingSource
+	}
+	return nil
+}
+
+func (c *JWTConfig) Validate() error {
+	if c.JWKS != nil {
+		if err := c.JWKS.Validate(); err != nil {
+			return err
+		}
+	} else if len(c.Keys) == 0 {
+		return domainErrors.ErrJWTMissingKeys
+	}
+
+	for _, key := range c.Keys {
+		if err := key.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *JWTKey) Validate() error {
+	switch k.Algorithm {
+	case JWTAlgorithmHS256:
+		if k.Secret == "" {
+			return domainErrors.ErrJWTMissingKeyMaterial
+		}
+	case JWTAlgorithmRS256, JWTAlgorithmES256:
+		if k.PublicKey == "" {
+			return domainErrors.ErrJWTMissingKeyMaterial
+		}
+	default:
+		return domainErrors.ErrJWTUnsupportedAlgorithm
+	}
+	return nil
+}
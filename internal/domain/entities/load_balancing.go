@@ -0,0 +1,59 @@
+package entities
+
+import domainErrors "api-gateway/internal/domain/errors"
+
+// Strategies picking the target of a backend that serves a request
+const (
+	// BalancerRoundRobin takes the targets in turn, ignoring weights
+	BalancerRoundRobin string = "round_robin"
+	// BalancerWeightedRoundRobin takes the targets in turn, in proportion to their weight
+	BalancerWeightedRoundRobin string = "weighted_round_robin"
+	// BalancerLeastConnections takes the target with the fewest requests in flight per weight
+	BalancerLeastConnections string = "least_connections"
+	// BalancerRandomTwoChoices takes the less loaded of two random targets
+	BalancerRandomTwoChoices string = "random_two_choices"
+	// BalancerConsistentHash sends requests with the same header or cookie value to the same target
+	BalancerConsistentHash string = "consistent_hash"
+)
+
+// MaxTargetWeight bounds target weights, consistent hashing places a number of
+// ring points per unit of weight
+const MaxTargetWeight = 100
+
+// Target is one upstream host serving a backend
+type Target struct {
+	Host   string `json:"host"`
+	Weight int    `json:"weight"`
+}
+
+// LoadBalancing selects how requests are spread over the targets of a backend
+type LoadBalancing struct {
+	Strategy string `json:"strategy"`
+	// HashHeader or HashCookie names the request value consistent hashing routes by,
+	// requests without it are balanced round robin
+	HashHeader string `json:"hashHeader,omitempty"`
+	HashCookie string `json:"hashCookie,omitempty"`
+}
+
+func (t *Target) Validate() error {
+	if err := validateHost(t.Host); err != nil {
+		return err
+	}
+	if t.Weight < 1 || t.Weight > MaxTargetWeight {
+		return domainErrors.ErrInvalidTargetWeight
+	}
+	return nil
+}
+
+func (l *LoadBalancing) Validate() error {
+	switch l.Strategy {
+	case "", BalancerRoundRobin, BalancerWeightedRoundRobin, BalancerLeastConnections, BalancerRandomTwoChoices:
+		return nil
+	case BalancerConsistentHash:
+		if (l.HashHeader == "") == (l.HashCookie == "") {
+			return domainErrors.ErrBalancerMissingHashKey
+		}
+		return nil
+	}
+	return domainErrors.ErrInvalidBalancerStrategy
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"crypto/x509"
+)
+
+// MTLSConfig restricts which verified client certificates a route accepts.
+// A certificate is allowed when it matches any entry of any list, or when
+// all lists are empty.
+type MTLSConfig struct {
+	// ClientCAs verifies certificates for this route only. When nil the
+	// listener must have verified the certificate already.
+	ClientCAs *x509.CertPool `json:"-"`
+	// Subjects match the common name or the full distinguished name, e.g.
+	// "CN=acme-billing,O=Acme Corp"
+	Subjects []string `json:"subjects,omitempty"`
+	DNSNames []string `json:"dnsNames,omitempty"`
+	URIs     []string `json:"uris,omitempty"`
+	Emails   []string `json:"emails,omitempty"`
+}
+
+// Allows reports whether the certificate identity matches the allow lists
+func (c *MTLSConfig) Allows(cert *x509.Certificate) bool {
+	if len(c.Subjects) == 0 && len(c.DNSNames) == 0 && len(c.URIs) == 0 && len(c.Emails) == 0 {
+		return true
+	}
+
+	for _, subject := range c.Subjects {
+		if subject == cert.Subject.CommonName || subject == cert.Subject.String() {
+			return true
+		}
+	}
+	if containsAny(c.DNSNames, cert.DNSNames) || containsAny(c.Emails, cert.EmailAddresses) {
+		return true
+	}
+	for _, uri := range cert.URIs {
+		if containsAny(c.URIs, []string{uri.String()}) {
+			return true
+		}
+	}
+	return false
+}
+
+// CertificateIdentity names the client of a certificate: its common name, or
+// the first URI, DNS name or email SAN when the common name is empty
+func CertificateIdentity(cert *x509.Certificate) string {
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0]
+	}
+	return ""
+}
+
+func containsAny(allowed, values []string) bool {
+	for _, value := range values {
+		for _, candidate := range allowed {
+			if candidate == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package entities_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"api-gateway/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMTLSConfig_Allows(t *testing.T) {
+	spiffe, _ := url.Parse("spiffe://partners/acme")
+	cert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "acme-billing", Organization: []string{"Acme Corp"}},
+		DNSNames:       []string{"billing.acme.example"},
+		URIs:           []*url.URL{spiffe},
+		EmailAddresses: []string{"ops@acme.example"},
+	}
+
+	tests := []struct {
+		name     string
+		config   *entities.MTLSConfig
+		expected bool
+	}{
+		{"empty allow lists accept any certificate", &entities.MTLSConfig{}, true},
+		{"common name", &entities.MTLSConfig{Subjects: []string{"acme-billing"}}, true},
+		{"distinguished name", &entities.MTLSConfig{Subjects: []string{"CN=acme-billing,O=Acme Corp"}}, true},
+		{"dns name", &entities.MTLSConfig{DNSNames: []string{"billing.acme.example"}}, true},
+		{"uri", &entities.MTLSConfig{URIs: []string{"spiffe://partners/acme"}}, true},
+		{"email", &entities.MTLSConfig{Emails: []string{"ops@acme.example"}}, true},
+		{"no match", &entities.MTLSConfig{Subjects: []string{"globex"}, DNSNames: []string{"globex.example"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.Allows(cert))
+		})
+	}
+}
+
+func TestCertificateIdentity(t *testing.T) {
+	spiffe, _ := url.Parse("spiffe://partners/acme")
+
+	assert.Equal(t, "acme-billing", entities.CertificateIdentity(&x509.Certificate{Subject: pkix.Name{CommonName: "acme-billing"}, URIs: []*url.URL{spiffe}}))
+	assert.Equal(t, "spiffe://partners/acme", entities.CertificateIdentity(&x509.Certificate{URIs: []*url.URL{spiffe}, DNSNames: []string{"acme.example"}}))
+	assert.Equal(t, "acme.example", entities.CertificateIdentity(&x509.Certificate{DNSNames: []string{"acme.example"}}))
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"time"
+)
+
+// OutlierDetection ejects a target from rotation after ConsecutiveErrors
+// failed requests of live traffic in a row. Each ejection of the same target
+// lasts BaseEjectionTime longer than the previous one, up to MaxEjectionTime,
+// and at most MaxEjectionPercent of the targets are ejected at once, though
+// always at least one.
+type OutlierDetection struct {
+	ConsecutiveErrors  int           `json:"consecutiveErrors"`
+	BaseEjectionTime   time.Duration `json:"baseEjectionTime"`
+	MaxEjectionTime    time.Duration `json:"maxEjectionTime"`
+	MaxEjectionPercent int           `json:"maxEjectionPercent"`
+}
+
+// TargetOutcome is what a finished request tells about the target that served it
+type TargetOutcome int
+
+const (
+	TargetOutcomeSuccess TargetOutcome = iota
+	// TargetOutcomeFailure is a request the target failed, a 5xx response, a
+	// connection error or a timeout
+	TargetOutcomeFailure
+	// TargetOutcomeIgnored is a request that tells nothing about the target,
+	// e.g. one the client canceled
+	TargetOutcomeIgnored
+)
+
+func (o *OutlierDetection) Validate() error {
+	if o.ConsecutiveErrors < 1 || o.BaseEjectionTime <= 0 || o.MaxEjectionTime < o.BaseEjectionTime {
+		return domainErrors.ErrInvalidOutlierDetection
+	}
+	if o.MaxEjectionPercent < 1 || o.MaxEjectionPercent > 100 {
+		return domainErrors.ErrInvalidOutlierDetection
+	}
+	return nil
+}
+
+// EjectionTime is how long the given ejection of a target in a row lasts, starting at 1
+func (o *OutlierDetection) EjectionTime(ejections int) time.Duration {
+	return min(o.BaseEjectionTime*time.Duration(ejections), o.MaxEjectionTime)
+}
+
+// MaxEjected is how many of the given number of targets may be out of
+// rotation at once. One is allowed even at low percentages, but the last
+// target is never ejected: a backend failing all requests does better serving
+// them than answering 503 for the whole ejection time.
+func (o *OutlierDetection) MaxEjected(targets int) int {
+	return min(max(1, targets*o.MaxEjectionPercent/100), targets-1)
+}
@@ -0,0 +1,28 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"api-gateway/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutlierDetection_EjectionTime(t *testing.T) {
+	detection := &entities.OutlierDetection{BaseEjectionTime: 30 * time.Second, MaxEjectionTime: 100 * time.Second}
+
+	assert.Equal(t, 30*time.Second, detection.EjectionTime(1))
+	assert.Equal(t, 90*time.Second, detection.EjectionTime(3))
+	assert.Equal(t, 100*time.Second, detection.EjectionTime(4), "ejections are capped")
+}
+
+func TestOutlierDetection_MaxEjected(t *testing.T) {
+	detection := &entities.OutlierDetection{MaxEjectionPercent: 50}
+
+	assert.Equal(t, 0, detection.MaxEjected(1), "the last target is never ejected")
+	assert.Equal(t, 1, detection.MaxEjected(2))
+	assert.Equal(t, 1, detection.MaxEjected(3))
+	assert.Equal(t, 1, (&entities.OutlierDetection{MaxEjectionPercent: 10}).MaxEjected(3), "one target can be ejected at low percentages")
+	assert.Equal(t, 5, detection.MaxEjected(10))
+}
@@ -0,0 +1,59 @@
+package entities
+
+// Principal is the identity established by a successful authentication
+type Principal struct {
+	Subject  string
+	AuthType string
+	KeyID    string
+	Scopes   []string
+	Roles    []string
+	Claims   map[string]interface{}
+	// Upstream holds header changes requested by an external authorization service
+	Upstream *HeaderMutation
+}
+
+// HeaderMutation lists headers to set and remove on the request forwarded to a backend
+type HeaderMutation struct {
+	Set    map[string]string
+	Remove []string
+}
+
+// Merge combines two mutations, the other's headers taking precedence
+func (m *HeaderMutation) Merge(other *HeaderMutation) *HeaderMutation {
+	if m == nil {
+		return other
+	}
+	if other == nil {
+		return m
+	}
+
+	merged := &HeaderMutation{Set: make(map[string]string, len(m.Set)+len(other.Set))}
+	for name, value := range m.Set {
+		merged.Set[name] = value
+	}
+	for name, value := range other.Set {
+		merged.Set[name] = value
+	}
+	merged.Remove = append(append(merged.Remove, m.Remove...), other.Remove...)
+	return merged
+}
+
+// HasScope reports whether the principal was granted the given scope
+func (p *Principal) HasScope(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole reports whether the principal holds the given role
+func (p *Principal) HasRole(role string) bool {
+	for _, granted := range p.Roles {
+		if granted == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"time"
+)
+
+// QuotaPeriod is the calendar period a quota counts requests over
+type QuotaPeriod string
+
+const (
+	QuotaPeriodDaily   QuotaPeriod = "daily"
+	QuotaPeriodMonthly QuotaPeriod = "monthly"
+)
+
+// QuotaPlan is a tier of request quotas assigned to API keys. A zero limit
+// leaves the period unlimited.
+type QuotaPlan struct {
+	Name    string `json:"name"`
+	Daily   int64  `json:"daily,omitempty"`
+	Monthly int64  `json:"monthly,omitempty"`
+}
+
+// QuotaPolicy holds the configured plans. Keys without a plan, or with a plan
+// that is not configured, are on the default plan.
+type QuotaPolicy struct {
+	Plans       map[string]*QuotaPlan
+	DefaultPlan string
+	// Location decides where calendar days and months begin
+	Location *time.Location
+}
+
+// QuotaWindow is the calendar period of a plan that a request falls into
+type QuotaWindow struct {
+	Period QuotaPeriod
+	// ID names the calendar period, e.g. 2026-10-17 or 2026-10
+	ID      string
+	Limit   int64
+	ResetAt time.Time
+}
+
+// QuotaUsage is the number of requests counted in a window
+type QuotaUsage struct {
+	QuotaWindow
+	Used int64
+}
+
+// QuotaDecision is the outcome of counting a request against a plan
+type QuotaDecision struct {
+	Plan    string
+	Allowed bool
+	Usage   []QuotaUsage
+}
+
+func (p *QuotaPlan) Validate() error {
+	if p.Daily < 0 || p.Monthly < 0 {
+		return domainErrors.ErrInvalidQuotaPlan
+	}
+	return nil
+}
+
+// Windows returns the limited windows of the plan that contain now, with
+// calendar boundaries in the location of now
+func (p *QuotaPlan) Windows(now time.Time) []QuotaWindow {
+	var windows []QuotaWindow
+	year, month, day := now.Date()
+	if p.Daily > 0 {
+		windows = append(windows, QuotaWindow{
+			Period:  QuotaPeriodDaily,
+			ID:      now.Format("2006-01-02"),
+			Limit:   p.Daily,
+			ResetAt: time.Date(year, month, day+1, 0, 0, 0, 0, now.Location()),
+		})
+	}
+	if p.Monthly > 0 {
+		windows = append(windows, QuotaWindow{
+			Period:  QuotaPeriodMonthly,
+			ID:      now.Format("2006-01"),
+			Limit:   p.Monthly,
+			ResetAt: time.Date(year, month+1, 1, 0, 0, 0, 0, now.Location()),
+		})
+	}
+	return windows
+}
+
+func (p *QuotaPolicy) Validate() error {
+	for _, plan := range p.Plans {
+		if err := plan.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.DefaultPlan != "" && p.Plans[p.DefaultPlan] == nil {
+		return domainErrors.ErrUnknownQuotaPlan
+	}
+	return nil
+}
+
+// Plan returns the named plan, the default plan when it is not configured, or
+// nil when neither exists and the key is not limited
+func (p *QuotaPolicy) Plan(name string) *QuotaPlan {
+	if plan, ok := p.Plans[name]; ok {
+		return plan
+	}
+	return p.Plans[p.DefaultPlan]
+}
+
+// Windows returns the windows of a plan that contain now
+func (p *QuotaPolicy) Windows(plan *QuotaPlan, now time.Time) []QuotaWindow {
+	if p.Location != nil {
+		now = now.In(p.Location)
+	}
+	return plan.Windows(now)
+}
+
+// Remaining is how many requests are left in the window
+func (u QuotaUsage) Remaining() int64 {
+	return max(0, u.Limit-u.Used)
+}
+
+// Exhausted reports whether no further request fits into the window
+func (u QuotaUsage) Exhausted() bool {
+	return u.Used >= u.Limit
+}
+
+// RetryAt is when a rejected caller can make requests again, the latest reset
+// of the exhausted windows
+func (d *QuotaDecision) RetryAt() time.Time {
+	var retryAt time.Time
+	for _, usage := range d.Usage {
+		if usage.Exhausted() && usage.ResetAt.After(retryAt) {
+			retryAt = usage.ResetAt
+		}
+	}
+	return retryAt
+}
@@ -0,0 +1,98 @@
+package entities_test
+
+import (
+	"api-gateway/internal/domain/entities"
+	domainErrors "api-gateway/internal/domain/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaPlan_Windows(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		plan entities.QuotaPlan
+		now  time.Time
+		want []entities.QuotaWindow
+	}{
+		{
+			name: "daily and monthly",
+			plan: entities.QuotaPlan{Daily: 100, Monthly: 1000},
+			now:  time.Date(2026, 10, 17, 15, 4, 5, 0, time.UTC),
+			want: []entities.QuotaWindow{
+				{Period: entities.QuotaPeriodDaily, ID: "2026-10-17", Limit: 100, ResetAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+				{Period: entities.QuotaPeriodMonthly, ID: "2026-10", Limit: 1000, ResetAt: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "unlimited periods have no window",
+			plan: entities.QuotaPlan{Monthly: 1000},
+			now:  time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC),
+			want: []entities.QuotaWindow{
+				{Period: entities.QuotaPeriodMonthly, ID: "2026-12", Limit: 1000, ResetAt: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "calendar of the location",
+			plan: entities.QuotaPlan{Daily: 100},
+			now:  time.Date(2026, 10, 17, 23, 30, 0, 0, time.UTC).In(berlin),
+			want: []entities.QuotaWindow{
+				{Period: entities.QuotaPeriodDaily, ID: "2026-10-18", Limit: 100, ResetAt: time.Date(2026, 10, 19, 0, 0, 0, 0, berlin)},
+			},
+		},
+		{
+			name: "unlimited plan",
+			plan: entities.QuotaPlan{},
+			now:  time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.plan.Windows(tt.now))
+		})
+	}
+}
+
+func TestQuotaPolicy_Plan(t *testing.T) {
+	policy := &entities.QuotaPolicy{
+		Plans: map[string]*entities.QuotaPlan{
+			"free": {Name: "free", Daily: 100},
+			"pro":  {Name: "pro", Daily: 10000},
+		},
+		DefaultPlan: "free",
+	}
+	require.NoError(t, policy.Validate())
+
+	assert.Equal(t, "pro", policy.Plan("pro").Name)
+	assert.Equal(t, "free", policy.Plan("").Name)
+	assert.Equal(t, "free", policy.Plan("enterprise").Name, "unknown plans fall back to the default")
+
+	policy.DefaultPlan = ""
+	assert.Nil(t, policy.Plan(""), "keys are unlimited without a default plan")
+
+	policy.DefaultPlan = "enterprise"
+	assert.ErrorIs(t, policy.Validate(), domainErrors.ErrUnknownQuotaPlan)
+
+	policy.DefaultPlan = "free"
+	policy.Plans["broken"] = &entities.QuotaPlan{Name: "broken", Daily: -1}
+	assert.ErrorIs(t, policy.Validate(), domainErrors.ErrInvalidQuotaPlan)
+}
+
+func TestQuotaDecision_RetryAt(t *testing.T) {
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	month := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	decision := &entities.QuotaDecision{Usage: []entities.QuotaUsage{
+		{QuotaWindow: entities.QuotaWindow{Period: entities.QuotaPeriodDaily, Limit: 10, ResetAt: day}, Used: 10},
+		{QuotaWindow: entities.QuotaWindow{Period: entities.QuotaPeriodMonthly, Limit: 100, ResetAt: month}, Used: 50},
+	}}
+	assert.Equal(t, day, decision.RetryAt())
+
+	decision.Usage[1].Used = 100
+	assert.Equal(t, month, decision.RetryAt(), "the later reset wins when both are exhausted")
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	domainErrors "api-gateway/internal/domain/errors"
+	"time"
+)
+
+// What requests share a rate limit bucket
+const (
+	// RateLimitKeyConsumer counts per API key or authenticated user, falling back to the client IP
+	RateLimitKeyConsumer string = "consumer"
+	RateLimitKeyIP       string = "ip"
+	// RateLimitKeyRoute counts every request to the route together
+	RateLimitKeyRoute string = "route"
+)
+
+// RateLimit allows Rate requests per second on average and bursts of up to
+// Burst requests. Limits with the same Name share their buckets, so the
+// default limit counts requests across all routes.
+type RateLimit struct {
+	Name  string  `json:"name"`
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+	KeyBy string  `json:"keyBy,omitempty"`
+}
+
+// RateLimitDecision is the outcome of counting a request against a limit
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long a rejected caller has to wait for the next request
+	RetryAfter time.Duration
+	// ResetAfter is how long until the full burst is available again
+	ResetAfter time.Duration
+}
+
+// KeyedByConsumer reports whether the bucket depends on who the caller is, so
+// the request can only be counted once authentication has run
+func (l *RateLimit) KeyedByConsumer() bool {
+	return l.KeyBy == "" || l.KeyBy == RateLimitKeyConsumer
+}
+
+func (l *RateLimit) Validate() error {
+	if l.Rate <= 0 || l.Burst < 1 {
+		return domainErrors.ErrInvalidRateLimit
+	}
+
+	switch l.KeyBy {
+	case "", RateLimitKeyConsumer, RateLimitKeyIP, RateLimitKeyRoute:
+		return nil
+	}
+	return domainErrors.ErrInvalidRateLimitKey
+}
@@ -0,0 +1,28 @@
+package entities_test
+
+import (
+	"api-gateway/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_KeyedByConsumer(t *testing.T) {
+	tests := []struct {
+		name  string
+		keyBy string
+		want  bool
+	}{
+		{name: "default", keyBy: "", want: true},
+		{name: "consumer", keyBy: entities.RateLimitKeyConsumer, want: true},
+		{name: "ip", keyBy: entities.RateLimitKeyIP, want: false},
+		{name: "route", keyBy: entities.RateLimitKeyRoute, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := &entities.RateLimit{Name: "default", Rate: 1, Burst: 1, KeyBy: tt.keyBy}
+			assert.Equal(t, tt.want, limit.KeyedByConsumer())
+		})
+	}
+}
@@ -20,8 +20,14 @@ type Route struct {
 	PathType PathType `json:"pathType,omitempty"`
 	Enabled  bool
 
-	Backend    *Backend
-	AuthPolicy *AuthPolicy `json:"authPolicy,omitempty"`
+	Backend       *Backend
+	AuthPolicy    *AuthPolicy          `json:"authPolicy,omitempty"`
+	Authorization *AuthorizationPolicy `json:"authorization,omitempty"`
+	RateLimit     *RateLimit           `json:"rateLimit,omitempty"`
+
+	// Params holds the values of the :name segments of the path the route was
+	// matched against, it is only set on the copy returned for a request
+	Params map[string]string `json:"-"`
 }
 
 func NewRoute(method, path, pathType string, enabled bool, backend *Backend, authPolicy *AuthPolicy) *Route {
@@ -38,9 +44,16 @@ func NewRoute(method, path, pathType string, enabled bool, backend *Backend, aut
 // Match checks if the route matches the incoming request
 // backendID is prepended to the route path before matching
 func (r *Route) Match(incomingPath, incomingMethod, backendID string) bool {
+	_, ok := r.MatchParams(incomingPath, incomingMethod, backendID)
+	return ok
+}
+
+// MatchParams works like Match and also returns the values of the :name path
+// segments, nil when the route path has none
+func (r *Route) MatchParams(incomingPath, incomingMethod, backendID string) (map[string]string, bool) {
 	// Check method match first (including wildcard)
 	if r.Method != "*" && r.Method != incomingMethod {
-		return false
+		return nil, false
 	}
 
 	// Prepend backend ID to route path
@@ -48,7 +61,7 @@ func (r *Route) Match(incomingPath, incomingMethod, backendID string) bool {
 
 	switch r.PathType {
 	case "exact":
-		return fullPath == incomingPath
+		return nil, fullPath == incomingPath
 
 	case "prefix":
 		// First try parameterized matching if path contains ":"
@@ -56,40 +69,43 @@ func (r *Route) Match(incomingPath, incomingMethod, backendID string) bool {
 			return r.matchParameterizedPath(incomingPath, fullPath)
 		}
 		// Otherwise do simple prefix matching
-		return strings.HasPrefix(incomingPath, fullPath)
+		return nil, strings.HasPrefix(incomingPath, fullPath)
 
 	default:
 		// Default to exact match
-		return fullPath == incomingPath
+		return nil, fullPath == incomingPath
 	}
 }
 
 // matchParameterizedPath handles paths with parameters like /users/:id
-func (r *Route) matchParameterizedPath(incomingPath, fullPath string) bool {
+// and captures the value of every parameter segment
+func (r *Route) matchParameterizedPath(incomingPath, fullPath string) (map[string]string, bool) {
 	routeParts := strings.Split(fullPath, "/")
 	pathParts := strings.Split(incomingPath, "/")
 
 	// Must have same number of segments
 	if len(routeParts) != len(pathParts) {
-		return false
+		return nil, false
 	}
 
+	params := make(map[string]string)
 	for i := 0; i < len(routeParts); i++ {
 		routePart := routeParts[i]
 		pathPart := pathParts[i]
 
 		// If it's a parameter (starts with :), it matches anything
 		if strings.HasPrefix(routePart, ":") {
+			params[routePart[1:]] = pathPart
 			continue
 		}
 
 		// Otherwise must match exactly
 		if routePart != pathPart {
-			return false
+			return nil, false
 		}
 	}
 
-	return true
+	return params, true
 }
 
 func (r *Route) IsEnabled() bool {
@@ -112,5 +128,27 @@ func (r *Route) Validate() error {
 	if r.Backend == nil {
 		return domainErrors.ErrRouteMissingBackend
 	}
+
+	if r.Backend.ConcurrencyLimit != nil {
+		if err := r.Backend.ConcurrencyLimit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if r.AuthPolicy != nil {
+		if err := r.AuthPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if r.Authorization != nil {
+		if err := r.Authorization.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if r.RateLimit != nil {
+		return r.RateLimit.Validate()
+	}
 	return nil
 }
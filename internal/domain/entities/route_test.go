@@ -91,6 +91,52 @@ func TestRoute_Match(t *testing.T) {
 	}
 }
 
+func TestRoute_MatchParams(t *testing.T) {
+	backend := &entities.Backend{Host: "http://service:8080", Id: "tenants"}
+
+	tests := []struct {
+		name         string
+		route        *entities.Route
+		incomingPath string
+		wantParams   map[string]string
+		shouldMatch  bool
+	}{
+		{
+			name:         "captures every parameter",
+			route:        &entities.Route{Path: "/:tenantId/orders/:id", Method: "GET", PathType: entities.PathTypePrefix, Backend: backend},
+			incomingPath: "/tenants/acme/orders/42",
+			wantParams:   map[string]string{"tenantId": "acme", "id": "42"},
+			shouldMatch:  true,
+		},
+		{
+			name:         "no params on mismatch",
+			route:        &entities.Route{Path: "/:tenantId/orders/:id", Method: "GET", PathType: entities.PathTypePrefix, Backend: backend},
+			incomingPath: "/tenants/acme/invoices/42",
+			shouldMatch:  false,
+		},
+		{
+			name:         "plain prefix has no params",
+			route:        &entities.Route{Path: "/orders", Method: "GET", PathType: entities.PathTypePrefix, Backend: backend},
+			incomingPath: "/tenants/orders/42",
+			shouldMatch:  true,
+		},
+		{
+			name:         "exact has no params",
+			route:        &entities.Route{Path: "/orders", Method: "GET", PathType: entities.PathTypeExact, Backend: backend},
+			incomingPath: "/tenants/orders",
+			shouldMatch:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, ok := tt.route.MatchParams(tt.incomingPath, "GET", "tenants")
+			assert.Equal(t, tt.shouldMatch, ok)
+			assert.Equal(t, tt.wantParams, params)
+		})
+	}
+}
+
 func TestRoute_IsEnabled(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -177,6 +223,39 @@ func TestRoute_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid rate limit",
+			route: &entities.Route{
+				ID:        "route-1",
+				Path:      "/api/users",
+				Method:    "GET",
+				Backend:   &entities.Backend{Host: "http://service:8080", Id: "user"},
+				RateLimit: &entities.RateLimit{Name: "route-1", Rate: 5, Burst: 10, KeyBy: entities.RateLimitKeyIP},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rate limit without burst",
+			route: &entities.Route{
+				ID:        "route-1",
+				Path:      "/api/users",
+				Method:    "GET",
+				Backend:   &entities.Backend{Host: "http://service:8080", Id: "user"},
+				RateLimit: &entities.RateLimit{Name: "route-1", Rate: 5},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rate limit with unknown key",
+			route: &entities.Route{
+				ID:        "route-1",
+				Path:      "/api/users",
+				Method:    "GET",
+				Backend:   &entities.Backend{Host: "http://service:8080", Id: "user"},
+				RateLimit: &entities.RateLimit{Name: "route-1", Rate: 5, Burst: 10, KeyBy: "tenant"},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
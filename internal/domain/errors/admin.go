@@ -0,0 +1,19 @@
+package errors
+
+// admin-api-specific domain errors
+var (
+	ErrAdminUnauthorized = &DomainError{
+		Code:    "ADMIN_UNAUTHORIZED",
+		Message: "missing or invalid admin token",
+	}
+
+	ErrAdminMissingToken = &DomainError{
+		Code:    "MISSING_ADMIN_TOKEN_ERROR",
+		Message: "admin api is enabled but no admin token is configured",
+	}
+
+	ErrInvalidApiKeyRequest = &DomainError{
+		Code:    "INVALID_API_KEY_REQUEST",
+		Message: "invalid api key request",
+	}
+)
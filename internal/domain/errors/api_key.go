@@ -0,0 +1,54 @@
+package errors
+
+// api-key-specific domain errors
+var (
+	ErrMissingApiKey = &DomainError{
+		Code:    "API_TOKEN_NOT_VALID",
+		Message: "missing api key",
+	}
+
+	ErrInvalidApiKey = &DomainError{
+		Code:    "API_TOKEN_NOT_VALID",
+		Message: "invalid api key",
+	}
+
+	ErrApiKeyNotFound = &DomainError{
+		Code:    "API_KEY_NOT_FOUND",
+		Message: "API key not found",
+	}
+
+	ErrApiKeyDisabled = &DomainError{
+		Code:    "API_KEY_DISABLED",
+		Message: "API key is disabled",
+	}
+
+	ErrApiKeyExpired = &DomainError{
+		Code:    "API_KEY_EXPIRED",
+		Message: "API key has expired",
+	}
+
+	ErrApiKeyMissingID = &DomainError{
+		Code:    "MISSING_API_KEY_ID_ERROR",
+		Message: "API key requires an id",
+	}
+
+	ErrApiKeyInvalidID = &DomainError{
+		Code:    "INVALID_API_KEY_ID_ERROR",
+		Message: "API key id must not contain a '.'",
+	}
+
+	ErrApiKeyMissingSecret = &DomainError{
+		Code:    "MISSING_API_KEY_SECRET_ERROR",
+		Message: "API key requires a salted secret hash",
+	}
+
+	ErrApiKeyMissingOwner = &DomainError{
+		Code:    "MISSING_API_KEY_OWNER_ERROR",
+		Message: "API key requires an owner",
+	}
+
+	ErrApiKeyInvalidExpiry = &DomainError{
+		Code:    "INVALID_API_KEY_EXPIRY_ERROR",
+		Message: "API key must expire after it was created",
+	}
+)
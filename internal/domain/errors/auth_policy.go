@@ -1,4 +1,229 @@
 package errors
 
 // auth-specific domain errors
-var ()
+var (
+	ErrAuthPolicyMissingJWTConfig = &DomainError{
+		Code:    "MISSING_JWT_CONFIG_ERROR",
+		Message: "JWT auth policy requires a jwt configuration",
+	}
+
+	ErrAuthPolicyMissingIntrospectionConfig = &DomainError{
+		Code:    "MISSING_INTROSPECTION_CONFIG_ERROR",
+		Message: "OAuth2 introspection auth policy requires an introspection configuration",
+	}
+
+	ErrAuthPolicyMissingHMACConfig = &DomainError{
+		Code:    "MISSING_HMAC_CONFIG_ERROR",
+		Message: "HMAC auth policy requires an hmac configuration",
+	}
+
+	ErrHMACMissingCredentials = &DomainError{
+		Code:    "MISSING_HMAC_CREDENTIALS_ERROR",
+		Message: "HMAC configuration requires at least one credential",
+	}
+
+	ErrHMACInvalidCredential = &DomainError{
+		Code:    "INVALID_HMAC_CREDENTIAL_ERROR",
+		Message: "HMAC credential requires an id and a secret",
+	}
+
+	ErrHMACDuplicateCredential = &DomainError{
+		Code:    "DUPLICATE_HMAC_CREDENTIAL_ERROR",
+		Message: "HMAC credential ids must be unique",
+	}
+
+	ErrAuthPolicyMissingBasicConfig = &DomainError{
+		Code:    "MISSING_BASIC_AUTH_CONFIG_ERROR",
+		Message: "Basic auth policy requires a basic configuration",
+	}
+
+	ErrBasicAuthMissingUsers = &DomainError{
+		Code:    "MISSING_BASIC_AUTH_USERS_ERROR",
+		Message: "Basic auth configuration requires an htpasswd file with at least one user",
+	}
+
+	ErrBasicAuthInvalidSource = &DomainError{
+		Code:    "INVALID_BASIC_AUTH_SOURCE_ERROR",
+		Message: "Basic auth source must be htpasswd or api_keys",
+	}
+
+	ErrAuthPolicyMissingPolicies = &DomainError{
+		Code:    "MISSING_AUTH_POLICIES_ERROR",
+		Message: "Composite auth policy requires at least one policy",
+	}
+
+	ErrInvalidAuthPolicyMatch = &DomainError{
+		Code:    "INVALID_AUTH_POLICY_MATCH_ERROR",
+		Message: "Composite auth policy match must be any_of or all_of",
+	}
+
+	ErrInvalidCompositePolicy = &DomainError{
+		Code:    "INVALID_COMPOSITE_POLICY_ERROR",
+		Message: "Composite auth policies can only combine enabled policies of other types than none and composite",
+	}
+
+	ErrAuthPolicyMissingExternalConfig = &DomainError{
+		Code:    "MISSING_EXTERNAL_AUTH_CONFIG_ERROR",
+		Message: "External auth policy requires an external configuration",
+	}
+
+	ErrExternalAuthMissingEndpoint = &DomainError{
+		Code:    "MISSING_EXTERNAL_AUTH_ENDPOINT_ERROR",
+		Message: "External auth configuration requires an endpoint",
+	}
+
+	ErrExternalAuthInvalidEndpoint = &DomainError{
+		Code:    "INVALID_EXTERNAL_AUTH_ENDPOINT_ERROR",
+		Message: "External auth endpoint must be an absolute URL",
+	}
+
+	ErrExternalAuthCacheWithoutForwardHeaders = &DomainError{
+		Code:    "EXTERNAL_AUTH_CACHE_WITHOUT_FORWARD_HEADERS_ERROR",
+		Message: "External auth decisions can only be cached with an explicit forward_headers list",
+	}
+
+	ErrIntrospectionMissingEndpoint = &DomainError{
+		Code:    "MISSING_INTROSPECTION_ENDPOINT_ERROR",
+		Message: "Introspection configuration requires an endpoint",
+	}
+
+	ErrIntrospectionInvalidEndpoint = &DomainError{
+		Code:    "INVALID_INTROSPECTION_ENDPOINT_ERROR",
+		Message: "Introspection endpoint must be an absolute URL",
+	}
+
+	ErrIntrospectionUnavailable = &DomainError{
+		Code:    "INTROSPECTION_UNAVAILABLE",
+		Message: "Token introspection is unavailable",
+	}
+
+	ErrUnsupportedAuthType = &DomainError{
+		Code:    "UNSUPPORTED_AUTH_TYPE",
+		Message: "Unsupported authentication policy type",
+	}
+
+	ErrJWTMissingKeys = &DomainError{
+		Code:    "MISSING_JWT_KEYS_ERROR",
+		Message: "JWT configuration requires at least one key or a JWKS source",
+	}
+
+	ErrJWKSMissingSource = &DomainError{
+		Code:    "MISSING_JWKS_SOURCE_ERROR",
+		Message: "JWKS configuration requires a url or a file",
+	}
+
+	ErrJWKSUnavailable = &DomainError{
+		Code:    "JWKS_UNAVAILABLE",
+		Message: "Signing keys are not available",
+	}
+
+	ErrJWTMissingKeyMaterial = &DomainError{
+		Code:    "MISSING_JWT_KEY_MATERIAL_ERROR",
+		Message: "JWT key is missing its secret or public key",
+	}
+
+	ErrJWTUnsupportedAlgorithm = &DomainError{
+		Code:    "UNSUPPORTED_JWT_ALGORITHM_ERROR",
+		Message: "JWT algorithm must be one of HS256, RS256 or ES256",
+	}
+
+	ErrMissingBearerToken = &DomainError{
+		Code:    "MISSING_BEARER_TOKEN",
+		Message: "Missing bearer token",
+	}
+
+	ErrMissingBasicCredentials = &DomainError{
+		Code:    "MISSING_BASIC_CREDENTIALS",
+		Message: "Missing basic auth credentials",
+	}
+
+	ErrInvalidBasicCredentials = &DomainError{
+		Code:    "INVALID_BASIC_CREDENTIALS",
+		Message: "Invalid user name or password",
+	}
+
+	ErrMissingSignature = &DomainError{
+		Code:    "MISSING_SIGNATURE",
+		Message: "Missing request signature",
+	}
+
+	ErrInvalidSignature = &DomainError{
+		Code:    "INVALID_SIGNATURE",
+		Message: "Request signature does not match",
+	}
+
+	ErrSignatureExpired = &DomainError{
+		Code:    "SIGNATURE_EXPIRED",
+		Message: "Request timestamp is outside the accepted window",
+	}
+
+	ErrSignatureReplayed = &DomainError{
+		Code:    "SIGNATURE_REPLAYED",
+		Message: "Request nonce was already used",
+	}
+
+	ErrReplayCheckUnavailable = &DomainError{
+		Code:    "REPLAY_CHECK_UNAVAILABLE",
+		Message: "Request nonces cannot be checked",
+	}
+
+	ErrMissingClientCertificate = &DomainError{
+		Code:    "MISSING_CLIENT_CERTIFICATE",
+		Message: "Missing client certificate",
+	}
+
+	ErrInvalidClientCertificate = &DomainError{
+		Code:    "INVALID_CLIENT_CERTIFICATE",
+		Message: "Client certificate is not trusted",
+	}
+
+	ErrClientCertificateNotAllowed = &DomainError{
+		Code:    "CLIENT_CERTIFICATE_NOT_ALLOWED",
+		Message: "Client certificate identity is not allowed on this route",
+	}
+
+	ErrExternalAuthorizationDenied = &DomainError{
+		Code:    "EXTERNAL_AUTHORIZATION_DENIED",
+		Message: "Request was denied by the authorization service",
+	}
+
+	ErrExternalAuthorizationUnavailable = &DomainError{
+		Code:    "EXTERNAL_AUTHORIZATION_UNAVAILABLE",
+		Message: "Authorization service is unavailable",
+	}
+
+	ErrInvalidToken = &DomainError{
+		Code:    "INVALID_TOKEN",
+		Message: "Invalid token",
+	}
+
+	ErrTokenInactive = &DomainError{
+		Code:    "TOKEN_INACTIVE",
+		Message: "Token is not active",
+	}
+
+	ErrTokenMissingScope = &DomainError{
+		Code:    "TOKEN_MISSING_SCOPE",
+		Message: "Token was not granted the required scopes",
+	}
+
+	ErrTokenExpired = &DomainError{
+		Code:    "TOKEN_EXPIRED",
+		Message: "Token has expired",
+	}
+
+	ErrTokenNotYetValid = &DomainError{
+		Code:    "TOKEN_NOT_YET_VALID",
+		Message: "Token is not valid yet",
+	}
+
+	ErrTokenInvalidIssuer = &DomainError{
+		Code:    "TOKEN_INVALID_ISSUER",
+		Message: "Token issuer is not accepted",
+	}
+
+	ErrTokenInvalidAudience = &DomainError{
+		Code:    "TOKEN_INVALID_AUDIENCE",
+		Message: "Token audience is not accepted",
+	}
+)
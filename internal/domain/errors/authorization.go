@@ -0,0 +1,34 @@
+package errors
+
+// authorization-specific domain errors
+var (
+	ErrForbidden = &DomainError{
+		Code:    "FORBIDDEN",
+		Message: "Caller is not allowed to access this route",
+	}
+
+	ErrInsufficientScope = &DomainError{
+		Code:    "FORBIDDEN_INSUFFICIENT_SCOPE",
+		Message: "Caller lacks the scopes required by this route",
+	}
+
+	ErrInsufficientRole = &DomainError{
+		Code:    "FORBIDDEN_INSUFFICIENT_ROLE",
+		Message: "Caller lacks the roles required by this route",
+	}
+
+	ErrAuthorizationRuleDenied = &DomainError{
+		Code:    "FORBIDDEN_RULE",
+		Message: "Request is not allowed by the authorization rule of this route",
+	}
+
+	ErrInvalidAuthorizationMatch = &DomainError{
+		Code:    "INVALID_AUTHORIZATION_MATCH_ERROR",
+		Message: "Authorization match must be all_of or any_of",
+	}
+
+	ErrInvalidAuthorizationRule = &DomainError{
+		Code:    "INVALID_AUTHORIZATION_RULE_ERROR",
+		Message: "Authorization rule is not a valid expression",
+	}
+)
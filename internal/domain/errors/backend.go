@@ -11,4 +11,52 @@ var (
 		Code:    "INVALID_HOST_ERROR",
 		Message: "Invalid host",
 	}
+
+	ErrBackendUnavailable = &DomainError{
+		Code:    "BACKEND_UNAVAILABLE",
+		Message: "No target of the backend is available",
+	}
+
+	ErrInvalidTargetWeight = &DomainError{
+		Code:    "INVALID_TARGET_WEIGHT_ERROR",
+		Message: "Target weight must be between 1 and 100",
+	}
+
+	ErrInvalidBalancerStrategy = &DomainError{
+		Code:    "INVALID_BALANCER_STRATEGY_ERROR",
+		Message: "Load balancer strategy must be round_robin, weighted_round_robin, least_connections, random_two_choices or consistent_hash",
+	}
+
+	ErrBalancerMissingHashKey = &DomainError{
+		Code:    "MISSING_BALANCER_HASH_KEY_ERROR",
+		Message: "Consistent hashing needs either a hash_header or a hash_cookie",
+	}
+
+	ErrInvalidHealthCheck = &DomainError{
+		Code:    "INVALID_HEALTH_CHECK_ERROR",
+		Message: "Health check needs an absolute path, a positive interval, a timeout no longer than the interval, thresholds of at least 1 and valid expected statuses",
+	}
+
+	ErrInvalidOutlierDetection = &DomainError{
+		Code:    "INVALID_OUTLIER_DETECTION_ERROR",
+		Message: "Outlier detection needs consecutive_errors of at least 1, a positive base_ejection_time no longer than max_ejection_time and a max_ejection_percent between 1 and 100",
+	}
+)
+
+// Backend load shedding domain errors
+var (
+	ErrBackendOverloaded = &DomainError{
+		Code:    "BACKEND_OVERLOADED",
+		Message: "Backend is at capacity, retry later",
+	}
+
+	ErrInvalidConcurrencyLimit = &DomainError{
+		Code:    "INVALID_CONCURRENCY_LIMIT_ERROR",
+		Message: "Concurrency limit needs a positive max_requests, a min_requests between 1 and max_requests for adaptive limits, and no negative queue settings",
+	}
+
+	ErrInvalidConcurrencyAlgorithm = &DomainError{
+		Code:    "INVALID_CONCURRENCY_ALGORITHM_ERROR",
+		Message: "Concurrency algorithm must be fixed, aimd or gradient",
+	}
 )
@@ -0,0 +1,19 @@
+package errors
+
+// Usage quota domain errors
+var (
+	ErrQuotaExceeded = &DomainError{
+		Code:    "QUOTA_EXCEEDED",
+		Message: "Usage quota of the api key plan exhausted, retry after it resets",
+	}
+
+	ErrInvalidQuotaPlan = &DomainError{
+		Code:    "INVALID_QUOTA_PLAN_ERROR",
+		Message: "Quota plan limits must not be negative",
+	}
+
+	ErrUnknownQuotaPlan = &DomainError{
+		Code:    "UNKNOWN_QUOTA_PLAN_ERROR",
+		Message: "Default quota plan is not configured",
+	}
+)
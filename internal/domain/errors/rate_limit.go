@@ -0,0 +1,19 @@
+package errors
+
+// Rate limit domain errors
+var (
+	ErrRateLimitExceeded = &DomainError{
+		Code:    "RATE_LIMIT_EXCEEDED",
+		Message: "Too many requests, retry later",
+	}
+
+	ErrInvalidRateLimit = &DomainError{
+		Code:    "INVALID_RATE_LIMIT_ERROR",
+		Message: "Rate limit needs a positive requests_per_second and burst",
+	}
+
+	ErrInvalidRateLimitKey = &DomainError{
+		Code:    "INVALID_RATE_LIMIT_KEY_ERROR",
+		Message: "Rate limit key_by must be consumer, ip or route",
+	}
+)
@@ -14,12 +14,88 @@ import (
 )
 
 type DatabaseConnections struct {
-	logger logger.Logger
-	redis  ports.ApiKeyRepository
+	logger     logger.Logger
+	apiKeys    ports.ApiKeyRepository
+	apiKeyName string
+	nonces     ports.NonceStore
+	limiter    ports.RateLimiter
+	quotas     ports.QuotaStore
 }
 
 func NewDatabaseConnections(cfg *config.Config, logger logger.Logger) (*DatabaseConnections, error) {
 	log := logger.With("component", "database_connections")
+
+	var apiKeyRepo ports.ApiKeyRepository
+	var nonces ports.NonceStore
+	var limiter ports.RateLimiter
+	var quotas ports.QuotaStore
+	switch cfg.ApiKeys.Store {
+	case config.ApiKeyStoreRedis, "":
+		client, err := newRedisClient(cfg, log)
+		if err != nil {
+			return nil, err
+		}
+		redisRepo, err := newRedisApiKeyRepository(client, log)
+		if err != nil {
+			return nil, err
+		}
+		apiKeyRepo = redisRepo
+		nonces = repositories.NewRedisNonceStore(client)
+		limiter = repositories.NewRedisRateLimiter(client, repositories.NewMemoryRateLimiter(), log)
+		quotas = repositories.NewRedisQuotaStore(client)
+		// The memory and file stores are served from process memory already
+		if cfg.ApiKeys.Cache.Enabled {
+			apiKeyRepo = repositories.NewCachedApiKeyRepository(redisRepo, repositories.ApiKeyCacheOptions{
+				Size:        cfg.ApiKeys.Cache.Size,
+				TTL:         cfg.ApiKeys.Cache.TTL,
+				NegativeTTL: cfg.ApiKeys.Cache.NegativeTTL,
+			}, log)
+			log.Info("API key cache enabled",
+				"size", cfg.ApiKeys.Cache.Size,
+				"ttl", cfg.ApiKeys.Cache.TTL,
+				"negative_ttl", cfg.ApiKeys.Cache.NegativeTTL)
+		}
+	case config.ApiKeyStoreMemory:
+		log.Warn("Using in-memory API key store, keys are lost on restart")
+		apiKeyRepo = repositories.NewMemoryApiKeyRepository(log)
+	case config.ApiKeyStoreFile:
+		if cfg.ApiKeys.File == "" {
+			return nil, fmt.Errorf("api_keys.file is required for the %s store", config.ApiKeyStoreFile)
+		}
+		fileRepo, err := repositories.NewFileApiKeyRepository(cfg.ApiKeys.File, log)
+		if err != nil {
+			return nil, err
+		}
+		apiKeyRepo = fileRepo
+	default:
+		return nil, fmt.Errorf("unsupported api key store %q", cfg.ApiKeys.Store)
+	}
+
+	if nonces == nil {
+		log.Info("Using in-memory nonce store, signed requests are only protected against replays per instance")
+		nonces = repositories.NewMemoryNonceStore()
+	}
+	if limiter == nil {
+		log.Info("Using in-memory rate limiter, limits are enforced per instance")
+		limiter = repositories.NewMemoryRateLimiter()
+	}
+	if quotas == nil {
+		log.Info("Using in-memory quota store, usage is counted per instance and lost on restart")
+		quotas = repositories.NewMemoryQuotaStore()
+	}
+
+	log.Info("All database connections established successfully", "api_key_store", cfg.ApiKeys.Store)
+	return &DatabaseConnections{
+		logger:     log,
+		apiKeys:    apiKeyRepo,
+		apiKeyName: apiKeyStoreName(cfg.ApiKeys.Store),
+		nonces:     nonces,
+		limiter:    limiter,
+		quotas:     quotas,
+	}, nil
+}
+
+func newRedisClient(cfg *config.Config, log logger.Logger) (*redis.Client, error) {
 	client := redis.NewClient(&redis.Options{
 		Addr:         fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
 		Password:     cfg.Redis.Password,
@@ -38,25 +114,55 @@ func NewDatabaseConnections(cfg *config.Config, logger logger.Logger) (*Database
 		return nil, fmt.Errorf("failed to connect to redis: %w", err)
 	}
 
-	logger.Info("Redis connection established",
+	log.Info("Redis connection established",
 		"host", cfg.Redis.Host,
 		"port", cfg.Redis.Port)
+	return client, nil
+}
 
+func newRedisApiKeyRepository(client *redis.Client, log logger.Logger) (ports.ApiKeyRepository, error) {
 	redisRepo := repositories.NewRedisApiKeyRepository(client, log)
+	if migrator, ok := redisRepo.(ports.ApiKeyMigrator); ok {
+		migrateCtx, cancelMigrate := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancelMigrate()
+		if _, err := migrator.MigrateLegacyKeys(migrateCtx); err != nil {
+			return nil, fmt.Errorf("failed to migrate plaintext api keys: %w", err)
+		}
+	}
+	return redisRepo, nil
+}
 
-	log.Info("All database connections established successfully")
-	return &DatabaseConnections{
-		logger: log,
-		redis:  redisRepo,
-	}, nil
+func apiKeyStoreName(store string) string {
+	if store == "" {
+		return config.ApiKeyStoreRedis
+	}
+	return store
 }
 
 func (d *DatabaseConnections) HealthCheck(ctx context.Context) map[string]error {
 	checks := make(map[string]error)
-	checks["redis"] = d.redis.HealthCheck(ctx)
+	checks[d.apiKeyName] = d.apiKeys.HealthCheck(ctx)
 	return checks
 }
 
 func (d *DatabaseConnections) GetApiKeyRepo() ports.ApiKeyRepository {
-	return d.redis
+	return d.apiKeys
+}
+
+// GetNonceStore returns the store for signed request nonces, shared through
+// Redis when the Redis key store is used
+func (d *DatabaseConnections) GetNonceStore() ports.NonceStore {
+	return d.nonces
+}
+
+// GetRateLimiter returns the limiter that counts requests against route rate
+// limits, shared through Redis when the Redis key store is used
+func (d *DatabaseConnections) GetRateLimiter() ports.RateLimiter {
+	return d.limiter
+}
+
+// GetQuotaStore returns the store counting API key usage quotas, shared
+// through Redis when the Redis key store is used
+func (d *DatabaseConnections) GetQuotaStore() ports.QuotaStore {
+	return d.quotas
 }
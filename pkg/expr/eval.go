@@ -0,0 +1,380 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// node is a parsed expression that evaluates to a value: nil, bool, float64,
+// string, []interface{} or map[string]interface{}
+type node interface {
+	eval(vars map[string]interface{}) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(map[string]interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+type variableNode struct {
+	name string
+}
+
+func (n *variableNode) eval(vars map[string]interface{}) (interface{}, error) {
+	return normalize(vars[n.name]), nil
+}
+
+type listNode struct {
+	items []node
+}
+
+func (n *listNode) eval(vars map[string]interface{}) (interface{}, error) {
+	list := make([]interface{}, 0, len(n.items))
+	for _, item := range n.items {
+		value, err := item.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+	return list, nil
+}
+
+// fieldNode reads a map entry, missing entries and fields of null are null
+type fieldNode struct {
+	target node
+	name   string
+}
+
+func (n *fieldNode) eval(vars map[string]interface{}) (interface{}, error) {
+	target, err := n.target.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	switch t := target.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return normalize(t[n.name]), nil
+	}
+	return nil, fmt.Errorf("cannot read field %q of %s", n.name, typeName(target))
+}
+
+// indexNode reads a map entry by key or a list item by position
+type indexNode struct {
+	target node
+	key    node
+}
+
+func (n *indexNode) eval(vars map[string]interface{}) (interface{}, error) {
+	target, err := n.target.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	key, err := n.key.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := target.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		name, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key must be a string, got %s", typeName(key))
+		}
+		return normalize(t[name]), nil
+	case []interface{}:
+		index, ok := key.(float64)
+		if !ok || index != float64(int(index)) {
+			return nil, fmt.Errorf("list index must be an integer, got %v", key)
+		}
+		if index < 0 || int(index) >= len(t) {
+			return nil, nil
+		}
+		return normalize(t[int(index)]), nil
+	}
+	return nil, fmt.Errorf("cannot index %s", typeName(target))
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) eval(vars map[string]interface{}) (interface{}, error) {
+	value, err := evalBool(n.operand, vars)
+	if err != nil {
+		return nil, err
+	}
+	return !value, nil
+}
+
+// logicalNode is && or ||, the right side is only evaluated when it decides the result
+type logicalNode struct {
+	op          string
+	left, right node
+}
+
+func (n *logicalNode) eval(vars map[string]interface{}) (interface{}, error) {
+	left, err := evalBool(n.left, vars)
+	if err != nil {
+		return nil, err
+	}
+	if (n.op == "&&" && !left) || (n.op == "||" && left) {
+		return left, nil
+	}
+	return evalBool(n.right, vars)
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n *compareNode) eval(vars map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "in":
+		return contains(right, left)
+	}
+
+	cmp, err := order(left, right)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+type callNode struct {
+	target  node
+	method  string
+	args    []node
+	pattern *regexp.Regexp
+}
+
+func (n *callNode) eval(vars map[string]interface{}) (interface{}, error) {
+	target, err := n.target.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.method {
+	case "size":
+		switch t := target.(type) {
+		case nil:
+			return float64(0), nil
+		case string:
+			return float64(len(t)), nil
+		case []interface{}:
+			return float64(len(t)), nil
+		case map[string]interface{}:
+			return float64(len(t)), nil
+		}
+		return nil, fmt.Errorf("size() is not defined on %s", typeName(target))
+
+	case "contains":
+		arg, err := n.args[0].eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		return contains(target, arg)
+	}
+
+	// startsWith, endsWith and matches only apply to strings, null never matches
+	if target == nil {
+		return false, nil
+	}
+	s, ok := target.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s() is not defined on %s", n.method, typeName(target))
+	}
+	if n.method == "matches" {
+		return n.pattern.MatchString(s), nil
+	}
+
+	arg, err := n.args[0].eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	affix, ok := arg.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s() needs a string argument, got %s", n.method, typeName(arg))
+	}
+	if n.method == "startsWith" {
+		return strings.HasPrefix(s, affix), nil
+	}
+	return strings.HasSuffix(s, affix), nil
+}
+
+func evalBool(n node, vars map[string]interface{}) (bool, error) {
+	value, err := n.eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a bool, got %s", typeName(value))
+	}
+	return b, nil
+}
+
+// contains reports whether a list holds an item, a map has a key or a string a substring
+func contains(container, item interface{}) (bool, error) {
+	switch c := container.(type) {
+	case nil:
+		return false, nil
+	case []interface{}:
+		for _, element := range c {
+			if equal(normalize(element), item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case map[string]interface{}:
+		key, ok := item.(string)
+		if !ok {
+			return false, nil
+		}
+		_, found := c[key]
+		return found, nil
+	case string:
+		s, ok := item.(string)
+		if !ok {
+			return false, fmt.Errorf("cannot look for %s in a string", typeName(item))
+		}
+		return strings.Contains(c, s), nil
+	}
+	return false, fmt.Errorf("cannot look for a value in %s", typeName(container))
+}
+
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(normalize(x[i]), normalize(y[i])) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, found := y[key]
+			if !found || !equal(normalize(value), normalize(other)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch a.(type) {
+	case nil, bool, float64, string:
+		return a == b
+	}
+	return false
+}
+
+// order compares two numbers or two strings
+func order(a, b interface{}) (int, error) {
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				return -1, nil
+			case x > y:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %s with %s", typeName(a), typeName(b))
+}
+
+// normalize converts the Go values found in variables to the types expressions work with
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case []string:
+		list := make([]interface{}, len(v))
+		for i, s := range v {
+			list[i] = s
+		}
+		return list
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for key, s := range v {
+			m[key] = s
+		}
+		return m
+	}
+	return value
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,56 @@
+// Package expr implements the small expression language used by route
+// authorization rules, for example
+//
+//	claims.tenant == path.params.tenantId && method in ["GET", "HEAD"]
+//
+// Expressions support string, number, bool, null and list literals, variables,
+// field access (a.b), indexing (a["b"], a[0]), the operators == != < <= > >=
+// in && || ! and parentheses, and the methods startsWith, endsWith, contains,
+// matches and size. Reading a missing field yields null instead of an error, so
+// rules about optional claims fail closed rather than erroring.
+package expr
+
+import "fmt"
+
+// Program is a compiled expression, safe for concurrent use
+type Program struct {
+	source string
+	root   node
+}
+
+// Compile parses source. When variables are given, any other top level name is
+// rejected, so typos are reported when the rule is loaded instead of silently
+// evaluating to null.
+func Compile(source string, variables ...string) (*Program, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+
+	p := &parser{tokens: tokens}
+	if len(variables) > 0 {
+		p.variables = make(map[string]bool, len(variables))
+		for _, name := range variables {
+			p.variables[name] = true
+		}
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("invalid expression: %w", p.unexpected("expected end of expression"))
+	}
+	return &Program{source: source, root: root}, nil
+}
+
+// Eval evaluates the program against vars, it must produce a bool
+func (p *Program) Eval(vars map[string]interface{}) (bool, error) {
+	return evalBool(p.root, vars)
+}
+
+// String returns the source the program was compiled from
+func (p *Program) String() string {
+	return p.source
+}
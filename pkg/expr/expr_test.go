@@ -0,0 +1,126 @@
+package expr_test
+
+import (
+	"testing"
+
+	"api-gateway/pkg/expr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgram_Eval(t *testing.T) {
+	vars := map[string]interface{}{
+		"method": "GET",
+		"path": map[string]interface{}{
+			"params": map[string]string{"tenantId": "acme"},
+		},
+		"claims": map[string]interface{}{
+			"tenant": "acme",
+			"level":  float64(3),
+			"groups": []interface{}{"ops", "billing"},
+			"org":    map[string]interface{}{"id": "o-1"},
+		},
+		"principal": map[string]interface{}{
+			"scopes": []string{"orders:read"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   bool
+	}{
+		{name: "claim matches path param and method", source: `claims.tenant == path.params.tenantId && method in ["GET","HEAD"]`, want: true},
+		{name: "method not in list", source: `method in ["POST", "PUT"]`, want: false},
+		{name: "not equal", source: `claims.tenant != "globex"`, want: true},
+		{name: "missing claim is null", source: `claims.missing == null`, want: true},
+		{name: "field of missing claim is null", source: `claims.missing.deeper == "x"`, want: false},
+		{name: "number comparison", source: `claims.level >= 2 && claims.level < 4`, want: true},
+		{name: "string list membership", source: `"orders:read" in principal.scopes`, want: true},
+		{name: "index access", source: `claims["org"]["id"] == "o-1" && claims.groups[1] == "billing"`, want: true},
+		{name: "index out of range is null", source: `claims.groups[5] == null`, want: true},
+		{name: "map key membership", source: `"tenant" in claims`, want: true},
+		{name: "negation and grouping", source: `!(method == "DELETE" || claims.level < 1)`, want: true},
+		{name: "or short circuits", source: `method == "GET" || claims.level.size() > 0`, want: true},
+		{name: "and short circuits", source: `method == "POST" && claims.level.size() > 0`, want: false},
+		{name: "startsWith", source: `path.params.tenantId.startsWith("ac")`, want: true},
+		{name: "endsWith", source: `path.params.tenantId.endsWith("me")`, want: true},
+		{name: "contains on string", source: `claims.tenant.contains("cm")`, want: true},
+		{name: "contains on list", source: `claims.groups.contains("ops")`, want: true},
+		{name: "matches", source: `claims.org.id.matches("^o-[0-9]+$")`, want: true},
+		{name: "size", source: `claims.groups.size() == 2 && "acme".size() == 4`, want: true},
+		{name: "single quoted string with escape", source: `'it\'s' == "it's"`, want: true},
+		{name: "list equality", source: `claims.groups == ["ops", "billing"]`, want: true},
+		{name: "null methods are false", source: `claims.missing.startsWith("a")`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := expr.Compile(tt.source)
+			require.NoError(t, err)
+
+			got, err := program.Eval(vars)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProgram_EvalErrors(t *testing.T) {
+	vars := map[string]interface{}{
+		"method": "GET",
+		"claims": map[string]interface{}{"level": float64(3)},
+	}
+
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{name: "result is not a bool", source: `method`},
+		{name: "ordering mismatched types", source: `claims.level < "4"`},
+		{name: "and on a non bool", source: `method && true`},
+		{name: "field of a string", source: `method.name == "x"`},
+		{name: "method on a number", source: `claims.level.startsWith("3")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := expr.Compile(tt.source)
+			require.NoError(t, err)
+
+			_, err = program.Eval(vars)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{name: "empty", source: ``, wantErr: "expected a value, got end of expression"},
+		{name: "unterminated string", source: `method == "GET`, wantErr: "unterminated string"},
+		{name: "unknown character", source: `method = "GET"`, wantErr: "unexpected character"},
+		{name: "trailing tokens", source: `method == "GET" "POST"`, wantErr: "expected end of expression"},
+		{name: "unclosed list", source: `method in ["GET"`, wantErr: `expected ","`},
+		{name: "unknown method", source: `method.lower() == "get"`, wantErr: `unknown method "lower"`},
+		{name: "wrong arity", source: `method.startsWith()`, wantErr: "takes 1 argument(s), got 0"},
+		{name: "non literal pattern", source: `method.matches(method)`, wantErr: "needs a string literal pattern"},
+		{name: "invalid pattern", source: `method.matches("(")`, wantErr: "invalid pattern"},
+		{name: "unknown variable", source: `claim.tenant == "acme"`, wantErr: `unknown variable "claim"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expr.Compile(tt.source, "method", "claims")
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// methods are the functions that can be called on a value, with their number of arguments
+var methods = map[string]int{
+	"startsWith": 1,
+	"endsWith":   1,
+	"contains":   1,
+	"matches":    1,
+	"size":       0,
+}
+
+type parser struct {
+	tokens    []token
+	pos       int
+	variables map[string]bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the punctuation or keyword text
+func (p *parser) accept(text string) bool {
+	t := p.peek()
+	if (t.kind == tokenPunct || t.kind == tokenIdent) && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return p.unexpected(fmt.Sprintf("expected %q", text))
+	}
+	return nil
+}
+
+func (p *parser) unexpected(reason string) error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return fmt.Errorf("%s, got end of expression", reason)
+	}
+	return fmt.Errorf("%s, got %q at position %d", reason, t.text, t.pos)
+}
+
+// parseOr parses: and ("||" and)*
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary ("&&" unary)*
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses: "!" unary | comparison
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses: member (("==" | "!=" | "<" | "<=" | ">" | ">=" | "in") member)?
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseMember()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">", "in"} {
+		if p.accept(op) {
+			right, err := p.parseMember()
+			if err != nil {
+				return nil, err
+			}
+			return &compareNode{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+// parseMember parses: primary ("." ident ["(" args ")"] | "[" expr "]")*
+func (p *parser) parseMember() (node, error) {
+	target, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.accept("."):
+			name := p.peek()
+			if name.kind != tokenIdent {
+				return nil, p.unexpected("expected field name")
+			}
+			p.pos++
+			if !p.accept("(") {
+				target = &fieldNode{target: target, name: name.text}
+				continue
+			}
+			call, err := p.parseCall(target, name)
+			if err != nil {
+				return nil, err
+			}
+			target = call
+
+		case p.accept("["):
+			key, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			target = &indexNode{target: target, key: key}
+
+		default:
+			return target, nil
+		}
+	}
+}
+
+// parseCall parses the arguments of a method call after the opening parenthesis
+func (p *parser) parseCall(target node, name token) (node, error) {
+	arity, ok := methods[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown method %q at position %d", name.text, name.pos)
+	}
+
+	var args []node
+	if !p.accept(")") {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.accept(")") {
+				break
+			}
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(args) != arity {
+		return nil, fmt.Errorf("method %q at position %d takes %d argument(s), got %d", name.text, name.pos, arity, len(args))
+	}
+
+	call := &callNode{target: target, method: name.text, args: args}
+	if name.text == "matches" {
+		// Patterns are compiled once, so only literals are accepted
+		var source string
+		isString := false
+		if pattern, isLiteral := args[0].(*literalNode); isLiteral {
+			source, isString = pattern.value.(string)
+		}
+		if !isString {
+			return nil, fmt.Errorf("method \"matches\" at position %d needs a string literal pattern", name.pos)
+		}
+		re, err := regexp.Compile(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern at position %d: %w", name.pos, err)
+		}
+		call.pattern = re
+	}
+	return call, nil
+}
+
+// parsePrimary parses literals, variables, lists and parenthesized expressions
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return &literalNode{value: t.value}, nil
+
+	case tokenNumber:
+		value, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+		}
+		return &literalNode{value: value}, nil
+
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		case "in":
+			p.pos--
+			return nil, p.unexpected("expected a value")
+		}
+		if p.variables != nil && !p.variables[t.text] {
+			return nil, fmt.Errorf("unknown variable %q at position %d", t.text, t.pos)
+		}
+		return &variableNode{name: t.text}, nil
+
+	case tokenPunct:
+		switch t.text {
+		case "(":
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+
+		case "[":
+			list := &listNode{}
+			if p.accept("]") {
+				return list, nil
+			}
+			for {
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				list.items = append(list.items, item)
+				if p.accept("]") {
+					return list, nil
+				}
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if t.kind != tokenEOF {
+		p.pos--
+	}
+	return nil, p.unexpected("expected a value")
+}